@@ -1,9 +1,22 @@
 package events
 
 import (
+	"crypto/rsa"
 	"database/sql"
+	"errors"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/daffc/imersao18/golang/internal/events/infra/auth"
+	"github.com/daffc/imersao18/golang/internal/events/infra/document"
+	"github.com/daffc/imersao18/golang/internal/events/infra/messaging"
+	"github.com/daffc/imersao18/golang/internal/events/infra/notification"
+	"github.com/daffc/imersao18/golang/internal/events/infra/realtime"
 	"github.com/daffc/imersao18/golang/internal/events/infra/repository"
 	"github.com/daffc/imersao18/golang/internal/events/infra/service"
 	"github.com/daffc/imersao18/golang/internal/events/usecase"
@@ -24,6 +37,76 @@ func main() {
 		panic(err)
 	}
 
+	orderRepo, err := repository.NewMysqlOrderRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	waitlistRepo, err := repository.NewMysqlWaitlistRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	transferRepo, err := repository.NewMysqlTicketTransferRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	notificationRepo, err := repository.NewMysqlNotificationRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	domainEventRepo, err := repository.NewMysqlDomainEventRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	webhookRepo, err := repository.NewMysqlWebhookRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	waitingRoomRepo, err := repository.NewMysqlWaitingRoomRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	customerRepo, err := repository.NewMysqlCustomerRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	organizationRepo, err := repository.NewMysqlOrganizationRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	venueRepo, err := repository.NewMysqlVenueRepository(db)
+	if err != nil {
+		panic(err)
+	}
+
+	// Eventos de domínio: gravados na outbox junto com cada alteração e
+	// publicados depois pelo relay.
+	domainEventBus := messaging.NewBus()
+	domainEventPublisher, err := newDomainEventPublisher()
+	if err != nil {
+		panic(err)
+	}
+
+	// Notificações por email: renderizadas a partir de templates e enviadas
+	// a partir da tabela de saída (outbox).
+	notificationRenderer, err := notification.NewTemplateRenderer()
+	if err != nil {
+		panic(err)
+	}
+	notificationService := domain.NewNotificationService(notificationRenderer)
+	emailSender, err := newEmailSender()
+	if err != nil {
+		panic(err)
+	}
+
 	// Definindo Partners
 	partnerBaseURLs := map[int]string{
 		1: "http://localjpst:9080/api1",
@@ -31,24 +114,351 @@ func main() {
 	}
 	partnerFactory := service.NewPartnerfactory(partnerBaseURLs)
 
+	// Gateway de pagamento em memória; cartões cujo hash começa com
+	// "declined" são recusados.
+	paymentGateway := service.NewFakePaymentGateway()
+
+	// Mudanças de lugares são transmitidas em tempo real; as últimas 500 de
+	// cada evento ficam guardadas para clientes que reconectam.
+	spotHub := realtime.NewSpotHub(500, 64)
+
 	// Definindo Rotas e HttpHandler
 	listEventsUseCase := usecase.NewListEvenetsUseCase(eventRepo)
 	getEventsUseCase := usecase.NewGetEventUseCase(eventRepo)
 	listSpotsUseCase := usecase.NewListSpotsUseCase(eventRepo)
-	buyTicketsUseCase := usecase.NewBuyTicketsUseCase(eventRepo, partnerFactory)
+	watchSpotsUseCase := usecase.NewWatchSpotsUseCase(eventRepo, spotHub)
+	holdSpotsUseCase := usecase.NewHoldSpotsUseCase(eventRepo, spotHub, 10*time.Minute)
+	releaseHoldUseCase := usecase.NewReleaseHoldUseCase(eventRepo, spotHub)
+	ageRatingPolicy, err := newAgeRatingPolicy()
+	if err != nil {
+		panic(err)
+	}
+	buyTicketsUseCase := usecase.NewBuyTicketsUseCase(eventRepo, orderRepo, partnerFactory, paymentGateway, ageRatingPolicy, spotHub, customerRepo, notificationRepo, notificationService)
+	generateSpotsUseCase := usecase.NewGenerateSpotsUseCase(eventRepo, domain.NewSpotService())
+	bestAvailableUseCase := usecase.NewBestAvailableUseCase(eventRepo, domain.NewSeatSelectionService(), spotHub, 10*time.Minute)
+	setPurchaseLimitsUseCase := usecase.NewSetPurchaseLimitsUseCase(eventRepo)
+	setSalesPhasesUseCase := usecase.NewSetSalesPhasesUseCase(eventRepo)
+
+	// Lista de espera: lugares liberados ficam retidos por 15 minutos para o
+	// próximo cliente da fila.
+	offerWaitlistSpotsUseCase := usecase.NewOfferWaitlistSpotsUseCase(eventRepo, waitlistRepo, domain.NewSeatSelectionService(), service.NewLogWaitlistNotifier(), spotHub, 15*time.Minute)
+	joinWaitlistUseCase := usecase.NewJoinWaitlistUseCase(eventRepo, waitlistRepo)
+	cancelTicketUseCase := usecase.NewCancelTicketUseCase(eventRepo, orderRepo, paymentGateway, offerWaitlistSpotsUseCase, spotHub, notificationRepo, notificationService)
+	expireHoldsUseCase := usecase.NewExpireHoldsUseCase(eventRepo, orderRepo, waitlistRepo, offerWaitlistSpotsUseCase, spotHub)
+
+	// Lembretes são enviados 24 horas antes do evento; envios que falham são
+	// tentados até 5 vezes, com espera crescente a partir de 1 minuto.
+	cancelEventUseCase := usecase.NewCancelEventUseCase(eventRepo, orderRepo, paymentGateway, notificationRepo, notificationService)
+	sendEventRemindersUseCase := usecase.NewSendEventRemindersUseCase(eventRepo, notificationRepo, notificationService, 24*time.Hour)
+	dispatchNotificationsUseCase := usecase.NewDispatchNotificationsUseCase(notificationRepo, emailSender, 50, 5, time.Minute)
+
+	// Eventos de domínio passam pelo barramento interno: os webhooks dos
+	// integradores assinam todos eles, e o publicador configurado também
+	// os recebe. Entregas que falham são tentadas até 8 vezes, com espera
+	// crescente a partir de 30 segundos, antes de irem para dead letters.
+	enqueueWebhookDeliveriesUseCase := usecase.NewEnqueueWebhookDeliveriesUseCase(webhookRepo)
+	domainEventBus.Subscribe(messaging.AllDomainEvents, enqueueWebhookDeliveriesUseCase.Execute)
+	if domainEventPublisher != nil {
+		domainEventBus.Subscribe(messaging.AllDomainEvents, domainEventPublisher.Publish)
+	}
+	relayDomainEventsUseCase := usecase.NewRelayDomainEventsUseCase(domainEventRepo, domainEventBus, 100)
+	registerWebhookUseCase := usecase.NewRegisterWebhookUseCase(webhookRepo)
+	listWebhookDeliveriesUseCase := usecase.NewListWebhookDeliveriesUseCase(webhookRepo)
+	retryWebhookDeliveryUseCase := usecase.NewRetryWebhookDeliveryUseCase(webhookRepo)
+	dispatchWebhookDeliveriesUseCase := usecase.NewDispatchWebhookDeliveriesUseCase(webhookRepo, messaging.NewHTTPWebhookSender(10*time.Second), 50, 8, 30*time.Second)
+
+	// Sala de espera: enquanto ativa, o checkout exige um token de admissão.
+	configureWaitingRoomUseCase := usecase.NewConfigureWaitingRoomUseCase(eventRepo, waitingRoomRepo)
+	getWaitingRoomUseCase := usecase.NewGetWaitingRoomUseCase(waitingRoomRepo)
+	joinWaitingRoomUseCase := usecase.NewJoinWaitingRoomUseCase(waitingRoomRepo)
+	getWaitingRoomEntryUseCase := usecase.NewGetWaitingRoomEntryUseCase(waitingRoomRepo)
+	validateAdmissionUseCase := usecase.NewValidateAdmissionUseCase(eventRepo, waitingRoomRepo)
+	restoreAdmissionUseCase := usecase.NewRestoreAdmissionUseCase(waitingRoomRepo)
+	admitWaitingRoomUseCase := usecase.NewAdmitWaitingRoomUseCase(waitingRoomRepo)
+
+	// Transferências de ingressos expiram em 48 horas se não forem aceitas.
+	transferTicketUseCase := usecase.NewTransferTicketUseCase(eventRepo, transferRepo, 48*time.Hour)
+	acceptTicketTransferUseCase := usecase.NewAcceptTicketTransferUseCase(eventRepo, transferRepo, partnerFactory, os.Getenv("NOTIFY_PARTNERS_OF_TRANSFERS") == "true")
+	listTicketHoldersUseCase := usecase.NewListTicketHoldersUseCase(eventRepo, transferRepo)
+	expireTicketTransfersUseCase := usecase.NewExpireTicketTransfersUseCase(transferRepo)
+
+	// Credenciais dos ingressos (QR code) valem até 12 horas após o início do
+	// evento.
+	credentialSigner, err := newCredentialSigner()
+	if err != nil {
+		panic(err)
+	}
+	getTicketCredentialUseCase := usecase.NewGetTicketCredentialUseCase(eventRepo, credentialSigner, 12*time.Hour)
+	checkInTicketUseCase := usecase.NewCheckInTicketUseCase(eventRepo, credentialSigner, domain.NewCheckInService())
+	getCheckInManifestUseCase := usecase.NewGetCheckInManifestUseCase(eventRepo, credentialSigner, 12*time.Hour)
+	listCredentialKeysUseCase := usecase.NewListCredentialKeysUseCase(credentialSigner)
+	getOrderTicketsDocumentUseCase := usecase.NewGetOrderTicketsDocumentUseCase(eventRepo, orderRepo, credentialSigner, document.NewPDFRenderer(), 12*time.Hour)
+	getCustomerProfileUseCase := usecase.NewGetCustomerProfileUseCase(customerRepo)
+	updateCustomerProfileUseCase := usecase.NewUpdateCustomerProfileUseCase(customerRepo)
+	listCustomerTicketsUseCase := usecase.NewListCustomerTicketsUseCase(customerRepo)
+	listCustomerOrdersUseCase := usecase.NewListCustomerOrdersUseCase(customerRepo)
+	resolveOrganizationUseCase := usecase.NewResolveOrganizationUseCase(organizationRepo)
+	createVenueUseCase := usecase.NewCreateVenueUseCase(organizationRepo, venueRepo)
+	addVenueLayoutUseCase := usecase.NewAddVenueLayoutUseCase(venueRepo)
+	listVenuesUseCase := usecase.NewListVenuesUseCase(venueRepo)
+	listVenueEventsUseCase := usecase.NewListVenueEventsUseCase(venueRepo)
+	createEventUseCase := usecase.NewCreateEventUseCase(eventRepo, organizationRepo, venueRepo, domain.NewSpotService())
 
 	eventsHandler := httpHandler.NewEventHandler(
 		listEventsUseCase,
 		getEventsUseCase,
 		listSpotsUseCase,
 		buyTicketsUseCase,
+		createEventUseCase,
+		generateSpotsUseCase,
+		bestAvailableUseCase,
+		setPurchaseLimitsUseCase,
+		setSalesPhasesUseCase,
+		cancelEventUseCase,
+	)
+	venuesHandler := httpHandler.NewVenuesHandler(
+		createVenueUseCase,
+		addVenueLayoutUseCase,
+		listVenuesUseCase,
+		listVenueEventsUseCase,
+	)
+	waitlistHandler := httpHandler.NewWaitlistHandler(joinWaitlistUseCase)
+	waitingRoomHandler := httpHandler.NewWaitingRoomHandler(
+		configureWaitingRoomUseCase,
+		getWaitingRoomUseCase,
+		joinWaitingRoomUseCase,
+		getWaitingRoomEntryUseCase,
+		validateAdmissionUseCase,
+		restoreAdmissionUseCase,
+	)
+	spotsStreamHandler := httpHandler.NewSpotsStreamHandler(watchSpotsUseCase, 15*time.Second)
+	// Origens (ex.: o frontend) autorizadas a abrir o WebSocket de seleção de
+	// lugares, separadas por vírgula.
+	var allowedOrigins []string
+	if origins := os.Getenv("WEBSOCKET_ALLOWED_ORIGINS"); origins != "" {
+		allowedOrigins = strings.Split(origins, ",")
+	}
+	seatSelectionHandler := httpHandler.NewSeatSelectionHandler(
+		watchSpotsUseCase,
+		holdSpotsUseCase,
+		releaseHoldUseCase,
+		realtime.NewSelectionRoom(),
+		allowedOrigins,
+	)
+	ticketsHandler := httpHandler.NewTicketsHandler(
+		cancelTicketUseCase,
+		transferTicketUseCase,
+		acceptTicketTransferUseCase,
+		listTicketHoldersUseCase,
+		getTicketCredentialUseCase,
+	)
+	checkInHandler := httpHandler.NewCheckInHandler(
+		checkInTicketUseCase,
+		getCheckInManifestUseCase,
+		listCredentialKeysUseCase,
 	)
+	ordersHandler := httpHandler.NewOrdersHandler(getOrderTicketsDocumentUseCase)
+	meHandler := httpHandler.NewMeHandler(
+		getCustomerProfileUseCase,
+		updateCustomerProfileUseCase,
+		listCustomerTicketsUseCase,
+		listCustomerOrdersUseCase,
+	)
+	webhooksHandler := httpHandler.NewWebhooksHandler(
+		registerWebhookUseCase,
+		listWebhookDeliveriesUseCase,
+		retryWebhookDeliveryUseCase,
+	)
+
+	// Autenticação: tokens JWT são opcionais, exceto nas rotas protegidas
+	// abaixo; rotas administrativas exigem o papel "admin" e as de portaria
+	// o papel "staff", ambos concedidos por organização.
+	tokenVerifier, err := newTokenVerifier()
+	if err != nil {
+		panic(err)
+	}
 
 	r := http.NewServeMux()
-	r.HandleFunc("GET /events", eventsHandler.ListEvents)
-	r.HandleFunc("GET /events/{eventId}", eventsHandler.GetEvent)
-	r.HandleFunc("GET /events/{eventId}/spots", eventsHandler.ListSpots)
-	r.HandleFunc("POST /checkout", eventsHandler.BuyTickets)
+	r.HandleFunc("GET /organization", httpHandler.RequireOrganization(httpHandler.GetOrganization))
+	r.HandleFunc("GET /events", httpHandler.RequireOrganization(eventsHandler.ListEvents))
+	r.HandleFunc("POST /events", httpHandler.RequireOrganization(httpHandler.RequireRole(eventsHandler.CreateEvent, domain.RoleAdmin)))
+	r.HandleFunc("GET /events/{eventId}", httpHandler.RequireOrganization(eventsHandler.GetEvent))
+	r.HandleFunc("GET /venues", httpHandler.RequireOrganization(venuesHandler.ListVenues))
+	r.HandleFunc("POST /venues", httpHandler.RequireOrganization(httpHandler.RequireRole(venuesHandler.CreateVenue, domain.RoleAdmin)))
+	r.HandleFunc("POST /venues/{venueId}/layouts", httpHandler.RequireOrganization(httpHandler.RequireRole(venuesHandler.AddVenueLayout, domain.RoleAdmin)))
+	r.HandleFunc("GET /venues/{venueId}/events", httpHandler.RequireOrganization(venuesHandler.ListVenueEvents))
+	r.HandleFunc("GET /events/{eventId}/spots", httpHandler.RequireOrganization(eventsHandler.ListSpots))
+	r.HandleFunc("POST /events/{eventId}/spots", httpHandler.RequireOrganization(httpHandler.RequireRole(eventsHandler.GenerateSpots, domain.RoleAdmin)))
+	r.HandleFunc("GET /events/{eventId}/spots/stream", httpHandler.RequireOrganization(spotsStreamHandler.StreamSpots))
+	r.HandleFunc("GET /events/{eventId}/seat-selection", httpHandler.RequireOrganization(seatSelectionHandler.SeatSelection))
+	r.HandleFunc("POST /events/{eventId}/best-available", httpHandler.RequireOrganization(eventsHandler.BestAvailable))
+	r.HandleFunc("PUT /events/{eventId}/purchase-limits", httpHandler.RequireOrganization(httpHandler.RequireRole(eventsHandler.SetPurchaseLimits, domain.RoleAdmin)))
+	r.HandleFunc("PUT /events/{eventId}/sales-phases", httpHandler.RequireOrganization(httpHandler.RequireRole(eventsHandler.SetSalesPhases, domain.RoleAdmin)))
+	r.HandleFunc("POST /events/{eventId}/cancel", httpHandler.RequireOrganization(httpHandler.RequireRole(eventsHandler.CancelEvent, domain.RoleAdmin)))
+	r.HandleFunc("POST /events/{eventId}/waitlist", httpHandler.RequireOrganization(waitlistHandler.JoinWaitlist))
+	r.HandleFunc("POST /events/{eventId}/check-ins", httpHandler.RequireOrganization(httpHandler.RequireRole(checkInHandler.CheckInTicket, domain.RoleStaff)))
+	r.HandleFunc("GET /events/{eventId}/check-in-manifest", httpHandler.RequireOrganization(httpHandler.RequireRole(checkInHandler.GetCheckInManifest, domain.RoleStaff)))
+	r.HandleFunc("GET /credential-keys", checkInHandler.ListCredentialKeys)
+	r.HandleFunc("PUT /events/{eventId}/waiting-room", httpHandler.RequireOrganization(httpHandler.RequireRole(waitingRoomHandler.ConfigureWaitingRoom, domain.RoleAdmin)))
+	r.HandleFunc("GET /events/{eventId}/waiting-room", httpHandler.RequireOrganization(waitingRoomHandler.GetWaitingRoom))
+	r.HandleFunc("POST /events/{eventId}/waiting-room/entries", httpHandler.RequireOrganization(waitingRoomHandler.JoinWaitingRoom))
+	r.HandleFunc("GET /events/{eventId}/waiting-room/entries/{token}", httpHandler.RequireOrganization(waitingRoomHandler.GetWaitingRoomEntry))
+	r.HandleFunc("POST /checkout", httpHandler.RequireOrganization(httpHandler.RequireAuth(waitingRoomHandler.RequireAdmission(eventsHandler.BuyTickets))))
+	r.HandleFunc("GET /orders/{orderId}/tickets.pdf", httpHandler.RequireOrganization(httpHandler.RequireAuth(ordersHandler.GetOrderTicketsPDF)))
+	r.HandleFunc("GET /me", httpHandler.RequireAuth(meHandler.GetProfile))
+	r.HandleFunc("PUT /me", httpHandler.RequireAuth(meHandler.UpdateProfile))
+	r.HandleFunc("GET /me/tickets", httpHandler.RequireAuth(meHandler.ListTickets))
+	r.HandleFunc("GET /me/orders", httpHandler.RequireAuth(meHandler.ListOrders))
+	r.HandleFunc("POST /tickets/{ticketId}/cancel", httpHandler.RequireOrganization(httpHandler.RequireAuth(ticketsHandler.CancelTicket)))
+	r.HandleFunc("POST /tickets/{ticketId}/transfers", httpHandler.RequireOrganization(httpHandler.RequireAuth(ticketsHandler.TransferTicket)))
+	r.HandleFunc("GET /tickets/{ticketId}/holders", httpHandler.RequireOrganization(httpHandler.RequireRole(ticketsHandler.ListTicketHolders, domain.RoleAdmin)))
+	r.HandleFunc("GET /tickets/{ticketId}/qr", httpHandler.RequireOrganization(httpHandler.RequireAuth(ticketsHandler.GetTicketQRCode)))
+	r.HandleFunc("POST /transfers/accept", httpHandler.RequireOrganization(ticketsHandler.AcceptTicketTransfer))
+	r.HandleFunc("POST /webhooks", httpHandler.RequireOrganization(httpHandler.RequireRole(webhooksHandler.RegisterWebhook, domain.RoleAdmin)))
+	r.HandleFunc("GET /webhooks/{webhookId}/deliveries", httpHandler.RequireOrganization(httpHandler.RequireRole(webhooksHandler.ListWebhookDeliveries, domain.RoleAdmin)))
+	r.HandleFunc("GET /webhooks/{webhookId}/dead-letters", httpHandler.RequireOrganization(httpHandler.RequireRole(webhooksHandler.ListWebhookDeadLetters, domain.RoleAdmin)))
+	r.HandleFunc("POST /webhooks/{webhookId}/deliveries/{deliveryId}/retry", httpHandler.RequireOrganization(httpHandler.RequireRole(webhooksHandler.RetryWebhookDelivery, domain.RoleAdmin)))
+
+	// Liberando holds e transferências expirados e enviando notificações
+	// periodicamente.
+	go func() {
+		for range time.Tick(30 * time.Second) {
+			if _, err := expireHoldsUseCase.Execute(); err != nil {
+				log.Printf("expire holds: %v", err)
+			}
+			if _, err := expireTicketTransfersUseCase.Execute(); err != nil {
+				log.Printf("expire ticket transfers: %v", err)
+			}
+			if _, err := sendEventRemindersUseCase.Execute(); err != nil {
+				log.Printf("send event reminders: %v", err)
+			}
+			if _, err := dispatchNotificationsUseCase.Execute(); err != nil {
+				log.Printf("dispatch notifications: %v", err)
+			}
+		}
+	}()
+
+	// Publicando os eventos de domínio da outbox, entregando webhooks e
+	// admitindo clientes das salas de espera com mais frequência.
+	go func() {
+		for range time.Tick(2 * time.Second) {
+			if _, err := relayDomainEventsUseCase.Execute(); err != nil {
+				log.Printf("relay domain events: %v", err)
+			}
+			if _, err := dispatchWebhookDeliveriesUseCase.Execute(); err != nil {
+				log.Printf("dispatch webhook deliveries: %v", err)
+			}
+			if _, err := admitWaitingRoomUseCase.Execute(); err != nil {
+				log.Printf("admit waiting room: %v", err)
+			}
+		}
+	}()
+
+	// Toda requisição é autenticada e vinculada à sua organização antes de
+	// chegar às rotas.
+	http.ListenAndServe(":8080", httpHandler.Authenticate(tokenVerifier, httpHandler.ResolveOrganization(resolveOrganizationUseCase, r)))
+}
+
+// newCredentialSigner carrega as chaves de assinatura das credenciais:
+//
+//	TICKET_SIGNING_KEYS="kid:seed-base64,..."       chaves privadas Ed25519
+//	TICKET_SIGNING_KEY_ID="kid"                     chave usada para assinar
+//	TICKET_VERIFICATION_KEYS="kid:pub-base64,..."   chaves antigas, só verificação
+//
+// Para rotacionar, adicione a nova chave, troque TICKET_SIGNING_KEY_ID e
+// mantenha a antiga até que as credenciais emitidas com ela expirem.
+func newCredentialSigner() (*service.Ed25519CredentialSigner, error) {
+	privateKeys, err := service.ParseEd25519PrivateKeys(os.Getenv("TICKET_SIGNING_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	publicKeys, err := service.ParseEd25519PublicKeys(os.Getenv("TICKET_VERIFICATION_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	return service.NewEd25519CredentialSigner(os.Getenv("TICKET_SIGNING_KEY_ID"), privateKeys, publicKeys)
+}
 
-	http.ListenAndServe(":8080", r)
+// newAgeRatingPolicy lê quantos anos abaixo da classificação indicativa um
+// menor acompanhado por um adulto na mesma compra pode ter:
+//
+//	AGE_RATING_ACCOMPANIED_MINOR_ALLOWANCE=2   (padrão; 0 desativa)
+//
+// Eventos com classificação 18 nunca admitem menores, acompanhados ou não.
+func newAgeRatingPolicy() (domain.AgeRatingPolicy, error) {
+	policy := domain.AgeRatingPolicy{AccompaniedMinorAllowance: 2}
+	if value := os.Getenv("AGE_RATING_ACCOMPANIED_MINOR_ALLOWANCE"); value != "" {
+		allowance, err := strconv.Atoi(value)
+		if err != nil || allowance < 0 {
+			return policy, errors.New("AGE_RATING_ACCOMPANIED_MINOR_ALLOWANCE must be a non-negative number of years")
+		}
+		policy.AccompaniedMinorAllowance = allowance
+	}
+	return policy, nil
+}
+
+// newEmailSender escolhe como as notificações são entregues:
+//
+//	NOTIFICATION_SENDER=smtp  usa SMTP_ADDR, SMTP_USERNAME, SMTP_PASSWORD
+//	NOTIFICATION_SENDER=file  grava arquivos .eml em NOTIFICATION_DIR
+//	NOTIFICATION_SENDER=log   escreve no log (padrão, para desenvolvimento)
+//
+// O remetente é NOTIFICATION_FROM.
+func newEmailSender() (domain.EmailSender, error) {
+	from := os.Getenv("NOTIFICATION_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	switch os.Getenv("NOTIFICATION_SENDER") {
+	case "smtp":
+		return notification.NewSMTPSender(os.Getenv("SMTP_ADDR"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	case "file":
+		dir := os.Getenv("NOTIFICATION_DIR")
+		if dir == "" {
+			dir = "notifications"
+		}
+		return notification.NewFileSender(dir, from)
+	default:
+		return notification.NewLogSender(), nil
+	}
+}
+
+// newDomainEventPublisher escolhe para onde, além do barramento interno, os
+// eventos de domínio vão:
+//
+//	DOMAIN_EVENTS_PUBLISHER=webhook  POST JSON para DOMAIN_EVENTS_WEBHOOK_URL
+//	DOMAIN_EVENTS_PUBLISHER=bus      apenas os assinantes do barramento interno
+//	DOMAIN_EVENTS_PUBLISHER=log      escreve no log (padrão, para desenvolvimento)
+func newDomainEventPublisher() (domain.DomainEventPublisher, error) {
+	switch os.Getenv("DOMAIN_EVENTS_PUBLISHER") {
+	case "bus":
+		return nil, nil
+	case "webhook":
+		url := os.Getenv("DOMAIN_EVENTS_WEBHOOK_URL")
+		if url == "" {
+			return nil, errors.New("DOMAIN_EVENTS_WEBHOOK_URL is required")
+		}
+		return messaging.NewWebhookPublisher(url), nil
+	default:
+		return messaging.NewLogPublisher(), nil
+	}
+}
+
+// newTokenVerifier configura a validação dos tokens de acesso:
+//
+//	JWT_HS256_SECRET="..."         segredo compartilhado para tokens HS256
+//	JWT_JWKS_FILE="jwks.json"      chaves públicas para tokens RS256
+//	JWT_ISSUER, JWT_AUDIENCE       verificados quando informados
+//
+// Ao menos um dos dois tipos de chave é obrigatório.
+func newTokenVerifier() (domain.TokenVerifier, error) {
+	var rsaKeys map[string]*rsa.PublicKey
+	if path := os.Getenv("JWT_JWKS_FILE"); path != "" {
+		var err error
+		if rsaKeys, err = auth.LoadJWKSFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return auth.NewJWTVerifier([]byte(os.Getenv("JWT_HS256_SECRET")), rsaKeys, os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE"))
 }
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// AdultAge is the age from which an attendee may accompany minors.
+const AdultAge = 18
+
+var (
+	ErrInvalidRating                = errors.New("invalid event rating")
+	ErrAttendeeRequired             = errors.New("attendee data is required for every spot of a rated event")
+	ErrAttendeeBirthDateRequired    = errors.New("attendee birth date is required")
+	ErrAttendeeInvalidBirthDate     = errors.New("attendee birth date must use the YYYY-MM-DD format")
+	ErrAttendeeBelowAgeRating       = errors.New("attendee does not meet the event age rating")
+	ErrAttendeeBirthDateInTheFuture = errors.New("attendee birth date must be in the past")
+)
+
+// Attendee is the person who will use a ticket.
+type Attendee struct {
+	Name      string
+	BirthDate time.Time
+}
+
+// MinimumAge returns the age required to attend an event with this rating.
+func (r Rating) MinimumAge() (int, error) {
+	switch r {
+	case RatingLivre:
+		return 0, nil
+	case Rating10:
+		return 10, nil
+	case Rating12:
+		return 12, nil
+	case Rating14:
+		return 14, nil
+	case Rating16:
+		return 16, nil
+	case Rating18:
+		return 18, nil
+	default:
+		return 0, ErrInvalidRating
+	}
+}
+
+// AgeAt returns the age in whole years of someone born at birthDate on the
+// given date.
+func AgeAt(birthDate, at time.Time) int {
+	age := at.Year() - birthDate.Year()
+	if at.Month() < birthDate.Month() || (at.Month() == birthDate.Month() && at.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// AgeRatingPolicy decides who may attend an event given its rating.
+//
+// AccompaniedMinorAllowance is how many years below the minimum age a minor
+// may be when an adult attendee is part of the same order; zero means
+// minors are never admitted below the rating. It never applies to events
+// rated Rating18, which are for adults only.
+type AgeRatingPolicy struct {
+	AccompaniedMinorAllowance int
+}
+
+// ValidateAttendees checks every attendee's age on the date of the event
+// at the venue.
+func (p AgeRatingPolicy) ValidateAttendees(event *Event, attendees []Attendee) error {
+	minimumAge, err := event.Rating.MinimumAge()
+	if err != nil {
+		return err
+	}
+	eventDate := event.LocalDate()
+
+	accompanied := false
+	for _, attendee := range attendees {
+		if attendee.BirthDate.IsZero() {
+			return ErrAttendeeBirthDateRequired
+		}
+		if attendee.BirthDate.After(time.Now()) {
+			return ErrAttendeeBirthDateInTheFuture
+		}
+		if AgeAt(attendee.BirthDate, eventDate) >= AdultAge {
+			accompanied = true
+		}
+	}
+
+	for _, attendee := range attendees {
+		age := AgeAt(attendee.BirthDate, eventDate)
+		if age >= minimumAge {
+			continue
+		}
+		if !accompanied || event.Rating == Rating18 || age < minimumAge-p.AccompaniedMinorAllowance {
+			return ErrAttendeeBelowAgeRating
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// agedAt returns an attendee turning age at the start of the given year.
+func agedAt(age, year int) Attendee {
+	return Attendee{Name: "attendee", BirthDate: date(year-age, time.January, 1)}
+}
+
+func TestAgeAt(t *testing.T) {
+	tests := []struct {
+		birthDate time.Time
+		at        time.Time
+		age       int
+	}{
+		{date(2000, time.June, 15), date(2018, time.June, 14), 17},
+		{date(2000, time.June, 15), date(2018, time.June, 15), 18},
+		{date(2000, time.February, 29), date(2018, time.February, 28), 17},
+		{date(2000, time.February, 29), date(2018, time.March, 1), 18},
+	}
+
+	for _, tt := range tests {
+		if age := AgeAt(tt.birthDate, tt.at); age != tt.age {
+			t.Errorf("AgeAt(%s, %s) = %d, want %d", tt.birthDate.Format(time.DateOnly), tt.at.Format(time.DateOnly), age, tt.age)
+		}
+	}
+}
+
+func TestAgeRatingPolicyValidateAttendees(t *testing.T) {
+	policy := AgeRatingPolicy{AccompaniedMinorAllowance: 2}
+	eventDate := date(2030, time.June, 15)
+	tests := []struct {
+		name      string
+		rating    Rating
+		attendees []Attendee
+		err       error
+	}{
+		{name: "free rating", rating: RatingLivre, attendees: []Attendee{agedAt(5, 2030)}},
+		{name: "old enough", rating: Rating16, attendees: []Attendee{agedAt(16, 2030)}},
+		{name: "minor alone", rating: Rating16, attendees: []Attendee{agedAt(15, 2030)}, err: ErrAttendeeBelowAgeRating},
+		{name: "accompanied minor within allowance", rating: Rating16, attendees: []Attendee{agedAt(40, 2030), agedAt(14, 2030)}},
+		{name: "accompanied minor below allowance", rating: Rating16, attendees: []Attendee{agedAt(40, 2030), agedAt(13, 2030)}, err: ErrAttendeeBelowAgeRating},
+		{name: "adults only", rating: Rating18, attendees: []Attendee{agedAt(40, 2030), agedAt(17, 2030)}, err: ErrAttendeeBelowAgeRating},
+		{name: "missing birth date", rating: Rating12, attendees: []Attendee{{Name: "attendee"}}, err: ErrAttendeeBirthDateRequired},
+		{name: "birth date in the future", rating: Rating12, attendees: []Attendee{{Name: "attendee", BirthDate: time.Now().AddDate(1, 0, 0)}}, err: ErrAttendeeBirthDateInTheFuture},
+		{name: "invalid rating", rating: Rating("L20"), attendees: []Attendee{agedAt(40, 2030)}, err: ErrInvalidRating},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{Rating: tt.rating, Date: eventDate}
+			if err := policy.ValidateAttendees(event, tt.attendees); !errors.Is(err, tt.err) {
+				t.Errorf("ValidateAttendees() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// An evening show in a UTC-3 venue is already the next day in UTC; ages are
+// counted on the date at the venue.
+func TestAgeRatingPolicyUsesVenueDate(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	event := &Event{
+		Rating:   Rating18,
+		Date:     time.Date(2030, time.June, 15, 22, 0, 0, 0, location),
+		Timezone: "America/Sao_Paulo",
+	}
+	turnsEighteenNextDay := Attendee{Name: "attendee", BirthDate: date(2012, time.June, 16)}
+	turnsEighteenThatDay := Attendee{Name: "attendee", BirthDate: date(2012, time.June, 15)}
+
+	policy := AgeRatingPolicy{}
+	if err := policy.ValidateAttendees(event, []Attendee{turnsEighteenNextDay}); !errors.Is(err, ErrAttendeeBelowAgeRating) {
+		t.Errorf("ValidateAttendees() error = %v, want %v", err, ErrAttendeeBelowAgeRating)
+	}
+	if err := policy.ValidateAttendees(event, []Attendee{turnsEighteenThatDay}); err != nil {
+		t.Errorf("ValidateAttendees() error = %v, want nil", err)
+	}
+}
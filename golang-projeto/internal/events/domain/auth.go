@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+type Role string
+
+const (
+	// RoleAdmin manages events, spots, limits and integrations, and may act
+	// on any order or ticket.
+	RoleAdmin Role = "admin"
+	// RoleStaff works the gates, checking tickets in. It is only granted
+	// inside the organizations listing the user as staff.
+	RoleStaff Role = "staff"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrInvalidToken    = errors.New("invalid or expired access token")
+	ErrForbidden       = errors.New("not allowed to access this resource")
+)
+
+// Principal is the authenticated user behind a request.
+type Principal struct {
+	UserId string
+	Email  string
+	Roles  []Role
+}
+
+// TokenVerifier validates an access token and returns whom it was issued
+// to.
+type TokenVerifier interface {
+	Verify(token string) (*Principal, error)
+}
+
+// HasRole reports whether the principal has any of the given roles. Admins
+// have every role.
+func (p *Principal) HasRole(roles ...Role) bool {
+	if p == nil {
+		return false
+	}
+	if slices.Contains(p.Roles, RoleAdmin) {
+		return true
+	}
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessOrder reports whether the principal placed the order, or is an
+// admin. Orders placed before authentication are matched by email.
+func (p *Principal) CanAccessOrder(order *Order) bool {
+	if p == nil {
+		return false
+	}
+	if p.HasRole(RoleAdmin) {
+		return true
+	}
+	if order.UserId != "" {
+		return order.UserId == p.UserId
+	}
+	return order.Email == NormalizeEmail(p.Email)
+}
+
+// CanAccessTicket reports whether the principal holds the ticket, or is an
+// admin.
+func (p *Principal) CanAccessTicket(ticket *Ticket) bool {
+	if p == nil {
+		return false
+	}
+	return p.HasRole(RoleAdmin) || ticket.HolderEmail == NormalizeEmail(p.Email)
+}
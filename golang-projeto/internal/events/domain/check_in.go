@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CheckInManifest lists the tickets that may still enter an event with
+// the credential version of their current holder. Gate devices download it
+// signed and check credentials against it while offline.
+type CheckInManifest struct {
+	KeyId      string
+	EventId    string
+	Tickets    []CheckInManifestTicket
+	IssuedAt   time.Time
+	ValidUntil time.Time
+}
+
+// CheckInManifestTicket is a ticket of a manifest; credentials older than
+// CredentialVersion were revoked by a transfer.
+type CheckInManifestTicket struct {
+	TicketId          string
+	CredentialVersion int
+}
+
+var (
+	ErrCheckInGateRequired     = errors.New("gate id is required")
+	ErrCheckInWrongEvent       = errors.New("ticket belongs to another event")
+	ErrCheckInTicketCancelled  = errors.New("ticket is cancelled")
+	ErrCheckInScannedInFuture  = errors.New("scan time cannot be in the future")
+	ErrCheckInInvalidScannedAt = errors.New("scan time must be an RFC 3339 timestamp")
+	ErrCheckInCredentialTicket = errors.New("ticket credential does not match ticket")
+	ErrCheckInNotInManifest    = errors.New("ticket is not in the check-in manifest")
+	ErrCheckInManifestExpired  = errors.New("check-in manifest expired")
+)
+
+// CheckInService validates scanned credentials against the ticket they
+// name.
+type CheckInService struct{}
+
+func NewCheckInService() *CheckInService {
+	return &CheckInService{}
+}
+
+// CheckIn marks ticket as used at gateId. The credential must be genuine,
+// within its validity window at scannedAt, issued for eventId and to the
+// current holder of the ticket.
+func (s *CheckInService) CheckIn(credential *TicketCredential, ticket *Ticket, eventId, gateId string, scannedAt time.Time) error {
+	if gateId == "" {
+		return ErrCheckInGateRequired
+	}
+	if scannedAt.After(time.Now()) {
+		return ErrCheckInScannedInFuture
+	}
+	if credential.EventId != eventId || ticket.EventId != eventId {
+		return ErrCheckInWrongEvent
+	}
+	if credential.TicketId != ticket.Id {
+		return ErrCheckInCredentialTicket
+	}
+	if err := credential.ValidateFor(ticket); err != nil {
+		return err
+	}
+	if err := credential.ValidateAt(scannedAt); err != nil {
+		return err
+	}
+	return ticket.CheckIn(gateId, scannedAt)
+}
+
+// Admit checks a credential scanned offline at the given time against the
+// manifest: the ticket must still be allowed in and the credential issued
+// to its current holder, within its validity window.
+func (m *CheckInManifest) Admit(credential *TicketCredential, at time.Time) error {
+	if !at.Before(m.ValidUntil) {
+		return ErrCheckInManifestExpired
+	}
+	if credential.EventId != m.EventId {
+		return ErrCheckInWrongEvent
+	}
+	for _, ticket := range m.Tickets {
+		if ticket.TicketId != credential.TicketId {
+			continue
+		}
+		if err := credential.ValidateVersion(ticket.CredentialVersion); err != nil {
+			return err
+		}
+		return credential.ValidateAt(at)
+	}
+	return ErrCheckInNotInManifest
+}
+
+// CheckIn marks the ticket as used. Entering twice is rejected with the
+// time and gate of the first entry.
+func (t *Ticket) CheckIn(gateId string, at time.Time) error {
+	switch t.Status {
+	case TicketStatusUsed:
+		return fmt.Errorf("%w at %s through gate %s", ErrTicketAlreadyUsed, t.UsedAt.UTC().Format(time.RFC3339), t.GateId)
+	case TicketStatusCancelled:
+		return ErrCheckInTicketCancelled
+	}
+
+	t.Status = TicketStatusUsed
+	t.UsedAt = at
+	t.GateId = gateId
+	t.record(DomainEventTicketCheckedIn, t.Id, t.EventId, map[string]any{
+		"gate_id": gateId,
+		"used_at": at.UTC(),
+	})
+	return nil
+}
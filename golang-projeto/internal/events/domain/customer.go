@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxCustomerNameLength = 120
+
+var (
+	ErrCustomerNotFound     = errors.New("customer not found")
+	ErrCustomerUserRequired = errors.New("customer user id is required")
+	ErrCustomerNameTooLong  = errors.New("customer name is too long")
+)
+
+// CustomerPreferences are the defaults used when the customer buys
+// tickets. An empty TicketType means no preference.
+type CustomerPreferences struct {
+	TicketType           TicketType
+	Zone                 string
+	AcceptObstructedView bool
+	MarketingEmails      bool
+}
+
+// Customer is the account of an authenticated buyer. Emails holds every
+// verified address the customer signed in with, the first being the
+// primary one. Orders and tickets bought by the customer carry its Id;
+// tickets received by transfer are matched by any of its Emails.
+type Customer struct {
+	Id          string
+	UserId      string
+	Name        string
+	Emails      []string
+	Preferences CustomerPreferences
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewCustomer opens the account of the given user on their first request.
+func NewCustomer(principal *Principal) (*Customer, error) {
+	if principal == nil || principal.UserId == "" {
+		return nil, ErrCustomerUserRequired
+	}
+
+	now := time.Now()
+	customer := &Customer{
+		Id:        uuid.New().String(),
+		UserId:    principal.UserId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	customer.AddEmail(principal.Email)
+	return customer, nil
+}
+
+// AddEmail links a verified email to the customer and reports whether it
+// was not linked yet.
+func (c *Customer) AddEmail(email string) bool {
+	email = NormalizeEmail(email)
+	if email == "" || slices.Contains(c.Emails, email) {
+		return false
+	}
+	c.Emails = append(c.Emails, email)
+	c.UpdatedAt = time.Now()
+	return true
+}
+
+// PrimaryEmail is the first email the customer signed in with.
+func (c *Customer) PrimaryEmail() string {
+	if len(c.Emails) == 0 {
+		return ""
+	}
+	return c.Emails[0]
+}
+
+// UpdateProfile replaces the name and preferences of the customer.
+func (c *Customer) UpdateProfile(name string, preferences CustomerPreferences) error {
+	name = strings.TrimSpace(name)
+	if len(name) > maxCustomerNameLength {
+		return ErrCustomerNameTooLong
+	}
+	if preferences.TicketType != "" && !IsValidTicketType(preferences.TicketType) {
+		return ErrInvalidTicketType
+	}
+
+	c.Name = name
+	c.Preferences = preferences
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// CustomerTicket is a ticket of the customer with the event it is for.
+// The event spots and tickets are not loaded.
+type CustomerTicket struct {
+	Ticket Ticket
+	Event  Event
+}
+
+// CustomerOrder is an order of the customer with the event it is for. The
+// event spots and tickets are not loaded.
+type CustomerOrder struct {
+	Order Order
+	Event Event
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Names of the domain events. They are part of the public contract with
+// the systems consuming them and must not change.
+const (
+	DomainEventTicketCreated     = "ticket.created"
+	DomainEventTicketCancelled   = "ticket.cancelled"
+	DomainEventTicketCheckedIn   = "ticket.checked_in"
+	DomainEventTicketTransferred = "ticket.transferred"
+	DomainEventSpotHeld          = "spot.held"
+	DomainEventSpotReserved      = "spot.reserved"
+	DomainEventOrderPlaced       = "order.placed"
+	DomainEventEventCancelled    = "event.cancelled"
+)
+
+// DomainEvent records a state change of an aggregate. Repositories store
+// the events recorded by an aggregate in the outbox, in the same
+// transaction as the change itself, and a relay publishes them later.
+// EventId is the id of the Event (show) the change belongs to.
+type DomainEvent struct {
+	Id          string
+	Name        string
+	AggregateId string
+	EventId     string
+	OccurredAt  time.Time
+	Payload     map[string]any
+}
+
+// MarshalJSON encodes the event as sent to other systems.
+func (e DomainEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Id          string         `json:"id"`
+		Name        string         `json:"name"`
+		AggregateId string         `json:"aggregate_id"`
+		EventId     string         `json:"event_id"`
+		OccurredAt  string         `json:"occurred_at"`
+		Payload     map[string]any `json:"payload"`
+	}{
+		Id:          e.Id,
+		Name:        e.Name,
+		AggregateId: e.AggregateId,
+		EventId:     e.EventId,
+		OccurredAt:  e.OccurredAt.UTC().Format(time.RFC3339),
+		Payload:     e.Payload,
+	})
+}
+
+// IsValidDomainEventName reports whether name is one of the published
+// domain events.
+func IsValidDomainEventName(name string) bool {
+	switch name {
+	case DomainEventTicketCreated, DomainEventTicketCancelled, DomainEventTicketCheckedIn, DomainEventTicketTransferred,
+		DomainEventSpotHeld, DomainEventSpotReserved, DomainEventOrderPlaced, DomainEventEventCancelled:
+		return true
+	}
+	return false
+}
+
+// DomainEventPublisher delivers domain events to other systems. Delivery
+// is at least once: consumers should deduplicate by Id.
+type DomainEventPublisher interface {
+	Publish(event DomainEvent) error
+}
+
+// domainEvents is embedded by aggregates to record their events until a
+// repository pulls them.
+type domainEvents struct {
+	events []DomainEvent
+}
+
+func (d *domainEvents) record(name, aggregateId, eventId string, payload map[string]any) {
+	d.events = append(d.events, DomainEvent{
+		Id:          uuid.New().String(),
+		Name:        name,
+		AggregateId: aggregateId,
+		EventId:     eventId,
+		OccurredAt:  time.Now(),
+		Payload:     payload,
+	})
+}
+
+// PullEvents returns the recorded events and forgets them.
+func (d *domainEvents) PullEvents() []DomainEvent {
+	events := d.events
+	d.events = nil
+	return events
+}
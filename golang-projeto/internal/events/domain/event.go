@@ -2,7 +2,10 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Rating string
@@ -16,29 +19,217 @@ const (
 	Rating18    Rating = "L18"
 )
 
+// SeatingMode tells whether tickets are sold for named spots or as a plain
+// quantity of general admission tickets.
+type SeatingMode string
+
+const (
+	SeatingModeSeated           SeatingMode = "seated"
+	SeatingModeGeneralAdmission SeatingMode = "general_admission"
+)
+
+// EventStatus tells whether an event still takes place.
+type EventStatus string
+
+const (
+	EventStatusScheduled EventStatus = "scheduled"
+	EventStatusCancelled EventStatus = "cancelled"
+)
+
+// Event is a show on a single date or, when it has a Recurrence, the parent
+// of the Sessions where the show actually happens. Tickets of a recurring
+// event are sold for each session, which is an event of its own with a
+// ParentId, its own spots, capacity and sales window. Date is an instant;
+// Timezone is the IANA time zone of the venue, used to show it locally.
 type Event struct {
-	Id           string
-	Name         string
-	Location     string
-	Organization string
-	Rating       Rating
-	Date         time.Time
-	ImageURL     string
-	Capacity     int
-	Price        float64
-	PartnerId    int
-	Spots        []Spot
-	Tickets      []Ticket
+	Id             string
+	ParentId       string
+	Name           string
+	Location       string
+	VenueId        string
+	OrganizationId string
+	Organization   string
+	Rating         Rating
+	Date           time.Time
+	Timezone       string
+	ImageURL       string
+	Capacity       int
+	Price          float64
+	PartnerId      int
+	SeatingMode    SeatingMode
+	SoldTickets    int
+	Status         EventStatus
+	SalesStartAt   time.Time
+	SalesEndAt     time.Time
+	SalesPhases    []SalesPhase
+	Recurrence     *RecurrenceRule
+	Sessions       []*Event
+	Spots          []Spot
+	Tickets        []Ticket
+
+	domainEvents
 }
 
 var (
 	ErrEventNameRequired          = errors.New("invalid event name")
 	ErrEventInvalidDate           = errors.New("event date must by in the future")
+	ErrEventInvalidDateFormat     = errors.New("event date must use the 2006-01-02T15:04 or the RFC 3339 format")
 	ErrEventCapacityLessEqualZero = errors.New("event capacity must be greater than zero")
 	ErrEventPriceEqualZero        = errors.New("event price must be greater or equal to zero")
 	ErrEventNotFound              = errors.New("event not found")
+	ErrEventInvalidSeatingMode    = errors.New("invalid event seating mode")
+	ErrEventCapacityExceeded      = errors.New("number of spots exceeds event capacity")
+	ErrEventSoldOut               = errors.New("not enough tickets left for this event")
+	ErrEventGeneralAdmission      = errors.New("general admission events do not have spots")
+	ErrEventSeated                = errors.New("seated events require spot names")
+	ErrEventInvalidQuantity       = errors.New("ticket quantity must be greater than zero")
+	ErrEventCancelled             = errors.New("event is cancelled")
+	ErrEventHasSessions           = errors.New("event has sessions, tickets are sold for each session")
+	ErrEventInvalidSalesWindow    = errors.New("event sales must start before they end")
+	ErrEventSalesNotStarted       = errors.New("ticket sales for this event have not started yet")
+	ErrEventSalesEnded            = errors.New("ticket sales for this event have ended")
 )
 
+// NewEvent schedules an event of an organization at one of its venues,
+// sold through one of the organization partners. The event starts as
+// general admission for the whole venue capacity; see Seat and
+// LimitCapacity.
+func NewEvent(organization *Organization, venue *Venue, name string, rating Rating, date time.Time, imageURL string, price float64, partnerId int) (*Event, error) {
+	if venue.OrganizationId != organization.Id {
+		return nil, ErrVenueNotFound
+	}
+	if !organization.HasPartner(partnerId) {
+		return nil, ErrOrganizationUnknownPartner
+	}
+	if _, err := rating.MinimumAge(); err != nil {
+		return nil, err
+	}
+
+	location := venue.Name
+	if venue.Address.City != "" {
+		location += ", " + venue.Address.City
+	}
+	event := &Event{
+		Id:             uuid.New().String(),
+		Name:           strings.TrimSpace(name),
+		Location:       location,
+		VenueId:        venue.Id,
+		OrganizationId: organization.Id,
+		Organization:   organization.Name,
+		Rating:         rating,
+		Date:           date,
+		Timezone:       venue.Timezone,
+		ImageURL:       imageURL,
+		Capacity:       venue.Capacity,
+		Price:          price,
+		PartnerId:      partnerId,
+		SeatingMode:    SeatingModeGeneralAdmission,
+		Status:         EventStatusScheduled,
+		Spots:          []Spot{},
+		Tickets:        []Ticket{},
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Seat makes the event seated, with as many tickets as the layout has
+// seats. Its spots are then created by SpotService.GenerateSpots.
+func (e *Event) Seat(layout *VenueLayout) error {
+	names, err := layout.Layout.SpotNames()
+	if err != nil {
+		return err
+	}
+	e.SeatingMode = SeatingModeSeated
+	e.Capacity = len(names)
+	return nil
+}
+
+// LimitCapacity sells fewer tickets than the venue holds.
+func (e *Event) LimitCapacity(capacity int, venue *Venue) error {
+	if capacity <= 0 {
+		return ErrEventCapacityLessEqualZero
+	}
+	if capacity > venue.Capacity {
+		return ErrVenueCapacityExceeded
+	}
+	e.Capacity = capacity
+	return nil
+}
+
+// OpenSales sets the sales window of the event: tickets are sold from start,
+// or right away when it is zero, until closeBefore the event starts.
+func (e *Event) OpenSales(start time.Time, closeBefore time.Duration) error {
+	if closeBefore < 0 {
+		return ErrEventInvalidSalesWindow
+	}
+	end := e.Date.Add(-closeBefore)
+	if !start.IsZero() && !start.Before(end) {
+		return ErrEventInvalidSalesWindow
+	}
+	e.SalesStartAt = start
+	e.SalesEndAt = end
+	return nil
+}
+
+// ScheduleSessions makes the event recurring, creating one session per
+// occurrence of rule in the venue time zone. Sessions copy the seating,
+// capacity and price of the event and close their sales as long before
+// they start as the event does. The event Date becomes the date of the
+// first session. Seated sessions still need their spots; see
+// SpotService.GenerateSpots.
+func (e *Event) ScheduleSessions(rule *RecurrenceRule, location *time.Location) ([]*Event, error) {
+	dates, err := rule.Occurrences(e.Date, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var closeBefore time.Duration
+	if !e.SalesEndAt.IsZero() {
+		closeBefore = e.Date.Sub(e.SalesEndAt)
+	}
+
+	sessions := make([]*Event, len(dates))
+	for i, date := range dates {
+		session := &Event{
+			Id:             uuid.New().String(),
+			ParentId:       e.Id,
+			Name:           e.Name,
+			Location:       e.Location,
+			VenueId:        e.VenueId,
+			OrganizationId: e.OrganizationId,
+			Organization:   e.Organization,
+			Rating:         e.Rating,
+			Date:           date,
+			Timezone:       e.Timezone,
+			ImageURL:       e.ImageURL,
+			Capacity:       e.Capacity,
+			Price:          e.Price,
+			PartnerId:      e.PartnerId,
+			SeatingMode:    e.SeatingMode,
+			Status:         EventStatusScheduled,
+			Spots:          []Spot{},
+			Tickets:        []Ticket{},
+		}
+		if !e.SalesEndAt.IsZero() {
+			if err := session.OpenSales(e.SalesStartAt, closeBefore); err != nil {
+				return nil, err
+			}
+		}
+		if err := session.Validate(); err != nil {
+			return nil, err
+		}
+		sessions[i] = session
+	}
+
+	e.Recurrence = rule
+	e.Sessions = sessions
+	e.Date = sessions[0].Date
+	e.SalesEndAt = sessions[len(sessions)-1].SalesEndAt
+	return sessions, nil
+}
+
 func (e *Event) Validate() error {
 	if e.Name == "" {
 		return ErrEventNameRequired
@@ -53,13 +244,126 @@ func (e *Event) Validate() error {
 	}
 
 	if e.Price < 0 {
-		return ErrEventCapacityLessEqualZero
+		return ErrEventPriceEqualZero
+	}
+
+	if e.SeatingMode != SeatingModeSeated && e.SeatingMode != SeatingModeGeneralAdmission {
+		return ErrEventInvalidSeatingMode
+	}
+
+	return nil
+}
+
+// TimeLocation returns the time zone of the event, UTC for events created
+// before venues had one.
+func (e *Event) TimeLocation() *time.Location {
+	if e.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// LocalDate returns the date of the event in its time zone.
+func (e *Event) LocalDate() time.Time {
+	return e.Date.In(e.TimeLocation())
+}
+
+func (e *Event) IsGeneralAdmission() bool {
+	return e.SeatingMode == SeatingModeGeneralAdmission
+}
+
+// RemainingCapacity returns how many tickets can still be sold.
+func (e *Event) RemainingCapacity() int {
+	return max(e.Capacity-e.SoldTickets, 0)
+}
+
+// HasSessions tells whether tickets are sold for the sessions of the event
+// instead of the event itself.
+func (e *Event) HasSessions() bool {
+	return e.Recurrence != nil
+}
+
+// IsSession tells whether the event is a session of a recurring event.
+func (e *Event) IsSession() bool {
+	return e.ParentId != ""
+}
+
+// CheckSalesWindow checks whether tickets can be sold at the given time.
+// The last sales phase, typically door sales, may run past the end of the
+// window.
+func (e *Event) CheckSalesWindow(at time.Time) error {
+	if !e.SalesStartAt.IsZero() && at.Before(e.SalesStartAt) {
+		return ErrEventSalesNotStarted
 	}
+	if !e.SalesEndAt.IsZero() && !at.Before(e.SalesEndAt) && !e.inLastSalesPhase(at) {
+		return ErrEventSalesEnded
+	}
+	return nil
+}
+
+// CanSell checks whether quantity more tickets fit in the event capacity.
+func (e *Event) CanSell(quantity int) error {
+	return e.canSell(quantity, 0)
+}
+
+// CanSellHeld is CanSell for tickets of a general admission hold, whose
+// capacity was already claimed when the hold was created.
+func (e *Event) CanSellHeld(quantity int, hold *Hold) error {
+	if quantity > hold.Quantity {
+		return ErrHoldQuantityExceeded
+	}
+	return e.canSell(quantity, hold.Quantity)
+}
 
+func (e *Event) canSell(quantity, held int) error {
+	if e.HasSessions() {
+		return ErrEventHasSessions
+	}
+	if e.IsCancelled() {
+		return ErrEventCancelled
+	}
+	if err := e.CheckSalesWindow(time.Now()); err != nil {
+		return err
+	}
+	if quantity <= 0 {
+		return ErrEventInvalidQuantity
+	}
+	if quantity > e.RemainingCapacity()+held {
+		return ErrEventSoldOut
+	}
+	return nil
+}
+
+func (e *Event) IsCancelled() bool {
+	return e.Status == EventStatusCancelled
+}
+
+// Cancel calls the event off. Its tickets are cancelled along with it by
+// the repository.
+func (e *Event) Cancel() error {
+	if e.IsCancelled() {
+		return ErrEventCancelled
+	}
+	e.Status = EventStatusCancelled
+	e.record(DomainEventEventCancelled, e.Id, e.Id, map[string]any{"name": e.Name})
 	return nil
 }
 
 func (e *Event) AddSpot(name string) (*Spot, error) {
+	if e.HasSessions() {
+		return nil, ErrEventHasSessions
+	}
+	if e.IsGeneralAdmission() {
+		return nil, ErrEventGeneralAdmission
+	}
+	if len(e.Spots) >= e.Capacity {
+		return nil, ErrEventCapacityExceeded
+	}
+
 	spot, err := NewSpot(e, name)
 
 	if err != nil {
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Hold temporarily blocks a group of spots for one customer so they can
+// complete checkout without competing for the same seats. Holds of general
+// admission events block Quantity tickets of the event capacity instead.
+// A hold bound to an Email can only be bought by that customer.
+type Hold struct {
+	Id         string
+	EventId    string
+	Spots      []*Spot
+	Quantity   int
+	Email      string
+	TicketType TicketType
+	ExpiresAt  time.Time
+}
+
+var (
+	ErrHoldNotFound         = errors.New("hold not found")
+	ErrHoldExpired          = errors.New("hold expired")
+	ErrHoldWithoutSpots     = errors.New("hold must contain at least one spot")
+	ErrHoldInvalidTimeout   = errors.New("hold duration must be greater than zero")
+	ErrHoldInvalidQuantity  = errors.New("hold quantity must be greater than zero")
+	ErrHoldQuantityExceeded = errors.New("more tickets requested than the hold keeps")
+	ErrHoldOtherCustomer    = errors.New("hold belongs to another customer")
+)
+
+func NewHold(event *Event, spots []*Spot, ticketType TicketType, duration time.Duration) (*Hold, error) {
+	if !IsValidTicketType(ticketType) {
+		return nil, ErrInvalidTicketType
+	}
+	if len(spots) == 0 {
+		return nil, ErrHoldWithoutSpots
+	}
+	if duration <= 0 {
+		return nil, ErrHoldInvalidTimeout
+	}
+
+	hold := &Hold{
+		Id:         uuid.New().String(),
+		EventId:    event.Id,
+		Spots:      spots,
+		TicketType: ticketType,
+		ExpiresAt:  time.Now().Add(duration),
+	}
+
+	for _, spot := range spots {
+		if err := spot.Hold(hold.Id, hold.ExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+	return hold, nil
+}
+
+// NewGeneralAdmissionHold blocks quantity tickets of a general admission
+// event. The repository claims them from the event capacity when the hold
+// is created and gives back what was not bought when it is released.
+func NewGeneralAdmissionHold(event *Event, quantity int, ticketType TicketType, duration time.Duration) (*Hold, error) {
+	if !event.IsGeneralAdmission() {
+		return nil, ErrEventSeated
+	}
+	if !IsValidTicketType(ticketType) {
+		return nil, ErrInvalidTicketType
+	}
+	if quantity <= 0 {
+		return nil, ErrHoldInvalidQuantity
+	}
+	if duration <= 0 {
+		return nil, ErrHoldInvalidTimeout
+	}
+
+	return &Hold{
+		Id:         uuid.New().String(),
+		EventId:    event.Id,
+		Quantity:   quantity,
+		TicketType: ticketType,
+		ExpiresAt:  time.Now().Add(duration),
+	}, nil
+}
+
+// BindTo reserves the hold for the customer with the given email.
+func (h *Hold) BindTo(email string) {
+	h.Email = NormalizeEmail(email)
+}
+
+// CanBeUsedBy checks that the customer ordering with email may buy the
+// hold.
+func (h *Hold) CanBeUsedBy(email string) error {
+	if h.Email != "" && h.Email != NormalizeEmail(email) {
+		return ErrHoldOtherCustomer
+	}
+	return nil
+}
+
+func (h *Hold) IsExpired(at time.Time) bool {
+	return !at.Before(h.ExpiresAt)
+}
+
+func (h *Hold) SpotNames() []string {
+	names := make([]string, len(h.Spots))
+	for i, spot := range h.Spots {
+		names[i] = spot.Name
+	}
+	return names
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationKind string
+
+const (
+	NotificationPurchaseConfirmation NotificationKind = "purchase_confirmation"
+	NotificationTicketCancelled      NotificationKind = "ticket_cancelled"
+	NotificationEventReminder        NotificationKind = "event_reminder"
+	NotificationEventCancelled       NotificationKind = "event_cancelled"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// Notification is an email waiting in the outbox. It is rendered when
+// enqueued, so a crash between enqueueing and sending loses nothing and
+// retries send exactly the same message. DedupKey identifies the fact being
+// notified (e.g. one reminder per event and recipient) so enqueueing it
+// twice is harmless.
+type Notification struct {
+	Id            string
+	Kind          NotificationKind
+	Recipient     string
+	Subject       string
+	Body          string
+	DedupKey      string
+	Status        NotificationStatus
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	SentAt        time.Time
+}
+
+// NotificationData is what templates can show. Order is only set for
+// purchase confirmations and Reason for cancellations.
+type NotificationData struct {
+	Recipient string
+	Event     *Event
+	Order     *Order
+	Tickets   []Ticket
+	Reason    string
+}
+
+// NotificationRenderer produces the subject and body of a notification.
+type NotificationRenderer interface {
+	Render(kind NotificationKind, data *NotificationData) (subject, body string, err error)
+}
+
+// EmailSender delivers a rendered notification.
+type EmailSender interface {
+	Send(to, subject, body string) error
+}
+
+var (
+	ErrNotificationRecipientRequired = errors.New("notification recipient is required")
+	ErrNotificationInvalidKind       = errors.New("invalid notification kind")
+)
+
+// NotificationService composes notifications ready to be stored in the
+// outbox.
+type NotificationService struct {
+	renderer NotificationRenderer
+}
+
+func NewNotificationService(renderer NotificationRenderer) *NotificationService {
+	return &NotificationService{renderer: renderer}
+}
+
+func IsValidNotificationKind(kind NotificationKind) bool {
+	switch kind {
+	case NotificationPurchaseConfirmation, NotificationTicketCancelled, NotificationEventReminder, NotificationEventCancelled:
+		return true
+	}
+	return false
+}
+
+func (s *NotificationService) Compose(kind NotificationKind, dedupKey string, data *NotificationData) (*Notification, error) {
+	if !IsValidNotificationKind(kind) {
+		return nil, ErrNotificationInvalidKind
+	}
+	recipient := NormalizeEmail(data.Recipient)
+	if recipient == "" {
+		return nil, ErrNotificationRecipientRequired
+	}
+
+	subject, body, err := s.renderer.Render(kind, data)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	if dedupKey == "" {
+		dedupKey = id
+	}
+
+	now := time.Now()
+	return &Notification{
+		Id:            id,
+		Kind:          kind,
+		Recipient:     recipient,
+		Subject:       subject,
+		Body:          body,
+		DedupKey:      dedupKey,
+		Status:        NotificationStatusPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
+
+func (n *Notification) MarkSent(at time.Time) {
+	n.Status = NotificationStatusSent
+	n.Attempts++
+	n.LastError = ""
+	n.SentAt = at
+}
+
+// MarkFailed records a failed delivery. The next attempt waits retryDelay,
+// doubled after every failure; after maxAttempts the notification is given
+// up as failed.
+func (n *Notification) MarkFailed(err error, at time.Time, maxAttempts int, retryDelay time.Duration) {
+	n.Attempts++
+	n.LastError = err.Error()
+	if n.Attempts >= maxAttempts {
+		n.Status = NotificationStatusFailed
+		return
+	}
+	n.NextAttemptAt = at.Add(retryDelay << (n.Attempts - 1))
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order groups the tickets bought together in a single checkout.
+type Order struct {
+	Id         string
+	EventId    string
+	HoldId     string
+	UserId     string
+	CustomerId string
+	Email      string
+	CardHash   string
+	Tickets    []Ticket
+	Total      float64
+	CreatedAt  time.Time
+
+	PaymentId     string
+	PaymentStatus PaymentStatus
+
+	domainEvents
+}
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderEmailRequired = errors.New("order email is required")
+)
+
+func NewOrder(event *Event, email, cardHash string) (*Order, error) {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return nil, ErrOrderEmailRequired
+	}
+
+	return &Order{
+		Id:        uuid.New().String(),
+		EventId:   event.Id,
+		Email:     email,
+		CardHash:  cardHash,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// PlaceFor links the order, and the tickets added after it, to the
+// account of the customer buying it.
+func (o *Order) PlaceFor(customer *Customer) {
+	o.UserId = customer.UserId
+	o.CustomerId = customer.Id
+}
+
+// AddTicket attaches ticket to the order and updates its total. The
+// ticket.created event is recorded on ticket, not on the order's copy, so
+// that it is stored once, when the ticket is.
+func (o *Order) AddTicket(ticket *Ticket) {
+	ticket.OrderId = o.Id
+	ticket.HolderEmail = o.Email
+	ticket.CustomerId = o.CustomerId
+	ticket.record(DomainEventTicketCreated, ticket.Id, ticket.EventId, map[string]any{
+		"order_id":     o.Id,
+		"spot":         ticket.spotName(),
+		"ticket_type":  ticket.TicketType,
+		"price":        ticket.Price,
+		"holder_email": ticket.HolderEmail,
+	})
+
+	ticketCopy := *ticket
+	ticketCopy.domainEvents = domainEvents{}
+	o.Tickets = append(o.Tickets, ticketCopy)
+	o.Total = RoundAmount(o.Total + ticket.Price)
+}
+
+// Authorize records the payment authorization obtained for the order.
+func (o *Order) Authorize(paymentId string) {
+	o.PaymentId = paymentId
+	o.PaymentStatus = PaymentStatusAuthorized
+}
+
+// Capture marks the authorized payment as charged, which completes the
+// order.
+func (o *Order) Capture() error {
+	if o.PaymentStatus != PaymentStatusAuthorized {
+		return ErrPaymentInvalidState
+	}
+	o.PaymentStatus = PaymentStatusCaptured
+
+	ticketIds := make([]string, len(o.Tickets))
+	for i, ticket := range o.Tickets {
+		ticketIds[i] = ticket.Id
+	}
+	o.record(DomainEventOrderPlaced, o.Id, o.EventId, map[string]any{
+		"email":      o.Email,
+		"total":      o.Total,
+		"ticket_ids": ticketIds,
+	})
+	return nil
+}
+
+// Void marks the authorized payment as released without charging.
+func (o *Order) Void() error {
+	if o.PaymentStatus != PaymentStatusAuthorized {
+		return ErrPaymentInvalidState
+	}
+	o.PaymentStatus = PaymentStatusVoided
+	return nil
+}
+
+// Refund marks the captured payment as refunded, in full or in part.
+func (o *Order) Refund() error {
+	if o.PaymentStatus != PaymentStatusCaptured && o.PaymentStatus != PaymentStatusRefunded {
+		return ErrPaymentInvalidState
+	}
+	o.PaymentStatus = PaymentStatusRefunded
+	return nil
+}
+
+// NormalizeEmail lowercases and trims an email so that the same address is
+// always counted as the same buyer.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
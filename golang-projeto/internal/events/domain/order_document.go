@@ -0,0 +1,16 @@
+package domain
+
+// OrderDocument gathers everything printed on the tickets of an order.
+// Credentials maps ticket ids to their signed credentials; cancelled
+// tickets have none.
+type OrderDocument struct {
+	Order       *Order
+	Event       *Event
+	Credentials map[string]string
+}
+
+// OrderDocumentRenderer turns an order into a printable document.
+type OrderDocumentRenderer interface {
+	RenderOrder(document *OrderDocument) ([]byte, error)
+	ContentType() string
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOrganizationNotFound       = errors.New("organization not found")
+	ErrOrganizationRequired       = errors.New("organization is required: use the X-Organization header or an organization host")
+	ErrOrganizationUnknownPartner = errors.New("partner does not belong to the organization")
+)
+
+// Branding is how the storefront of an organization looks.
+type Branding struct {
+	LogoURL      string
+	PrimaryColor string
+	SupportEmail string
+}
+
+// Organization is a tenant: a producer selling its own events, through its
+// own partners, managed by its own admins and checked in by its own staff. Requests are bound to an
+// organization by its Slug, sent in the X-Organization header, or by one
+// of its Hosts.
+type Organization struct {
+	Id           string
+	Slug         string
+	Name         string
+	Hosts        []string
+	PartnerIds   []int
+	AdminUserIds []string
+	StaffUserIds []string
+	Branding     Branding
+	CreatedAt    time.Time
+}
+
+// HasPartner reports whether the organization sells through the partner.
+func (o *Organization) HasPartner(partnerId int) bool {
+	return slices.Contains(o.PartnerIds, partnerId)
+}
+
+// Authorize returns the principal as seen inside the organization: its
+// admins are granted RoleAdmin and its staff RoleStaff there, and only
+// there. RoleStaff carried by the token is dropped, as it does not say
+// which organization the gates belong to. Admins of the platform, whose
+// tokens carry RoleAdmin, keep it everywhere.
+func (o *Organization) Authorize(principal *Principal) *Principal {
+	if principal == nil || principal.HasRole(RoleAdmin) {
+		return principal
+	}
+	scoped := *principal
+	scoped.Roles = slices.DeleteFunc(slices.Clone(principal.Roles), func(role Role) bool {
+		return role == RoleStaff
+	})
+	if slices.Contains(o.AdminUserIds, principal.UserId) {
+		scoped.Roles = append(scoped.Roles, RoleAdmin)
+	}
+	if slices.Contains(o.StaffUserIds, principal.UserId) {
+		scoped.Roles = append(scoped.Roles, RoleStaff)
+	}
+	return &scoped
+}
+
+// NormalizeHost lowercases a host and strips its port, so that a request
+// Host header can be matched against the organization hosts.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	return strings.TrimSuffix(host, ".")
+}
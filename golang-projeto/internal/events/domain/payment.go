@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+)
+
+// PaymentGateway charges customers by card hash. An authorization reserves
+// the amount on the card; it is then either captured, once the purchase is
+// complete, or voided. Captured payments can be refunded, in full or in
+// part. Reference identifies the purchase on the gateway side, so retrying
+// an authorization with the same reference does not charge twice.
+type PaymentGateway interface {
+	Authorize(cardHash string, amount float64, reference string) (authorizationId string, err error)
+	Capture(authorizationId string, amount float64) error
+	Void(authorizationId string) error
+	Refund(authorizationId string, amount float64) error
+}
+
+var (
+	ErrPaymentCardHashRequired = errors.New("card hash is required")
+	ErrPaymentInvalidAmount    = errors.New("payment amount must be greater than zero")
+	ErrPaymentDeclined         = errors.New("payment declined")
+	ErrPaymentNotFound         = errors.New("payment authorization not found")
+	ErrPaymentInvalidState     = errors.New("payment is not in a state that allows this operation")
+	ErrPaymentAmountExceeded   = errors.New("amount exceeds the authorized or captured amount")
+)
+
+// TicketPrice returns the price of one ticket of the given type when a
+// full ticket costs price.
+func TicketPrice(price float64, ticketType TicketType) float64 {
+	ticket := Ticket{TicketType: ticketType, Price: price}
+	ticket.CalculatePrice()
+	return ticket.Price
+}
+
+// RoundAmount rounds an amount to cents, the precision payments work with.
+func RoundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
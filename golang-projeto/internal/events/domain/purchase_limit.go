@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PurchaseLimitKind identifies which limit an order hit.
+type PurchaseLimitKind string
+
+const (
+	PurchaseLimitPerOrder  PurchaseLimitKind = "per_order"
+	PurchaseLimitPerEmail  PurchaseLimitKind = "per_email"
+	PurchaseLimitPerCard   PurchaseLimitKind = "per_card"
+	PurchaseLimitPerWindow PurchaseLimitKind = "per_window"
+)
+
+// PurchaseLimits caps how many tickets of an event a buyer may get. A zero
+// value disables the corresponding limit. MaxPerWindow counts the tickets
+// bought with the same email within the last Window.
+type PurchaseLimits struct {
+	EventId      string
+	MaxPerOrder  int
+	MaxPerEmail  int
+	MaxPerCard   int
+	MaxPerWindow int
+	Window       time.Duration
+}
+
+// PurchaseHistory is how many tickets of an event a buyer already has.
+type PurchaseHistory struct {
+	ByEmail         int
+	ByCard          int
+	ByEmailInWindow int
+}
+
+var (
+	ErrPurchaseLimitExceeded       = errors.New("purchase limit exceeded")
+	ErrPurchaseOrderLimitExceeded  = errors.New("too many tickets in a single order")
+	ErrPurchaseLimitInvalid        = errors.New("purchase limits must not be negative")
+	ErrPurchaseLimitWindowRequired = errors.New("purchase limit window is required when limiting tickets per window")
+)
+
+// PurchaseLimitError describes the limit an order would exceed.
+type PurchaseLimitError struct {
+	Kind      PurchaseLimitKind
+	Max       int
+	Current   int
+	Requested int
+}
+
+func (e *PurchaseLimitError) Error() string {
+	return fmt.Sprintf("purchase limit %s exceeded: at most %d tickets allowed, %d already bought, %d requested",
+		e.Kind, e.Max, e.Current, e.Requested)
+}
+
+func (e *PurchaseLimitError) Unwrap() error {
+	if e.Kind == PurchaseLimitPerOrder {
+		return ErrPurchaseOrderLimitExceeded
+	}
+	return ErrPurchaseLimitExceeded
+}
+
+func (l *PurchaseLimits) Validate() error {
+	if l.MaxPerOrder < 0 || l.MaxPerEmail < 0 || l.MaxPerCard < 0 || l.MaxPerWindow < 0 || l.Window < 0 {
+		return ErrPurchaseLimitInvalid
+	}
+	if l.MaxPerWindow > 0 && l.Window == 0 {
+		return ErrPurchaseLimitWindowRequired
+	}
+	return nil
+}
+
+// TracksHistory reports whether any limit depends on the tickets the buyer
+// already has, as opposed to only capping a single order.
+func (l *PurchaseLimits) TracksHistory() bool {
+	return l.MaxPerEmail > 0 || l.MaxPerCard > 0 || l.MaxPerWindow > 0
+}
+
+// Check verifies that buying quantity more tickets keeps the buyer within
+// every configured limit.
+func (l *PurchaseLimits) Check(quantity int, history PurchaseHistory) error {
+	checks := []struct {
+		kind    PurchaseLimitKind
+		max     int
+		current int
+	}{
+		{PurchaseLimitPerOrder, l.MaxPerOrder, 0},
+		{PurchaseLimitPerEmail, l.MaxPerEmail, history.ByEmail},
+		{PurchaseLimitPerCard, l.MaxPerCard, history.ByCard},
+		{PurchaseLimitPerWindow, l.MaxPerWindow, history.ByEmailInWindow},
+	}
+
+	for _, check := range checks {
+		if check.max > 0 && check.current+quantity > check.max {
+			return &PurchaseLimitError{Kind: check.kind, Max: check.max, Current: check.current, Requested: quantity}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPurchaseLimitsCheck(t *testing.T) {
+	limits := PurchaseLimits{MaxPerOrder: 4, MaxPerEmail: 6, MaxPerCard: 8, MaxPerWindow: 5, Window: time.Hour}
+	tests := []struct {
+		name     string
+		limits   PurchaseLimits
+		quantity int
+		history  PurchaseHistory
+		kind     PurchaseLimitKind
+		err      error
+	}{
+		{name: "within every limit", limits: limits, quantity: 4, history: PurchaseHistory{ByEmail: 2, ByCard: 4, ByEmailInWindow: 1}},
+		{name: "no limits", limits: PurchaseLimits{}, quantity: 1000, history: PurchaseHistory{ByEmail: 1000}},
+		{name: "order limit", limits: limits, quantity: 5, kind: PurchaseLimitPerOrder, err: ErrPurchaseOrderLimitExceeded},
+		{name: "email limit", limits: limits, quantity: 1, history: PurchaseHistory{ByEmail: 6}, kind: PurchaseLimitPerEmail, err: ErrPurchaseLimitExceeded},
+		{name: "card limit", limits: limits, quantity: 2, history: PurchaseHistory{ByCard: 7}, kind: PurchaseLimitPerCard, err: ErrPurchaseLimitExceeded},
+		{name: "window limit", limits: limits, quantity: 2, history: PurchaseHistory{ByEmailInWindow: 4}, kind: PurchaseLimitPerWindow, err: ErrPurchaseLimitExceeded},
+		{name: "exactly at the limit", limits: limits, quantity: 1, history: PurchaseHistory{ByEmail: 5, ByEmailInWindow: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Check(tt.quantity, tt.history)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Check() error = %v, want %v", err, tt.err)
+			}
+			var limitErr *PurchaseLimitError
+			if errors.As(err, &limitErr) && limitErr.Kind != tt.kind {
+				t.Errorf("Check() kind = %s, want %s", limitErr.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestPurchaseLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits PurchaseLimits
+		err    error
+	}{
+		{name: "valid", limits: PurchaseLimits{MaxPerOrder: 1, MaxPerWindow: 2, Window: time.Minute}},
+		{name: "negative", limits: PurchaseLimits{MaxPerCard: -1}, err: ErrPurchaseLimitInvalid},
+		{name: "window without duration", limits: PurchaseLimits{MaxPerWindow: 2}, err: ErrPurchaseLimitWindowRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
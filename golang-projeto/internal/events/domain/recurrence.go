@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// maxEventSessions bounds how many sessions a recurrence rule may create.
+const maxEventSessions = 366
+
+var (
+	ErrRecurrenceInvalidFrequency = errors.New("recurrence frequency must be daily or weekly")
+	ErrRecurrenceInvalidInterval  = errors.New("recurrence interval must be greater than zero")
+	ErrRecurrenceInvalidWeekday   = errors.New("recurrence weekdays must be English day names, like monday")
+	ErrRecurrenceEndRequired      = errors.New("recurrence requires either an until date or a count of sessions")
+	ErrRecurrenceTooManySessions  = errors.New("recurrence creates too many sessions")
+	ErrRecurrenceNoSessions       = errors.New("recurrence does not create any session")
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily  RecurrenceFrequency = "daily"
+	RecurrenceWeekly RecurrenceFrequency = "weekly"
+)
+
+// RecurrenceRule describes when the sessions of an event happen, like a
+// small subset of the iCalendar RRULE: every Interval days or weeks,
+// optionally only on some Weekdays, until a date or for Count sessions.
+// Sessions keep the wall clock time of the first one in the venue time
+// zone, across daylight saving changes.
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency
+	Interval  int
+	Weekdays  []time.Weekday
+	Until     time.Time
+	Count     int
+}
+
+func (r *RecurrenceRule) Validate() error {
+	if r.Frequency != RecurrenceDaily && r.Frequency != RecurrenceWeekly {
+		return ErrRecurrenceInvalidFrequency
+	}
+	if r.Interval <= 0 {
+		return ErrRecurrenceInvalidInterval
+	}
+	if r.Until.IsZero() && r.Count <= 0 {
+		return ErrRecurrenceEndRequired
+	}
+	if r.Count > maxEventSessions {
+		return ErrRecurrenceTooManySessions
+	}
+	return nil
+}
+
+// Occurrences returns the start of every session, the first one being
+// start itself when it falls on one of the Weekdays.
+func (r *RecurrenceRule) Occurrences(start time.Time, location *time.Location) ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	start = start.In(location)
+	year, month, day := start.Date()
+	hour, minute, second := start.Clock()
+	at := func(offset int) time.Time {
+		return time.Date(year, month, day+offset, hour, minute, second, 0, location)
+	}
+
+	var dates []time.Time
+	for offset := 0; ; offset++ {
+		date := at(offset)
+		if !r.Until.IsZero() && date.After(r.Until) {
+			break
+		}
+		if r.Count > 0 && len(dates) == r.Count {
+			break
+		}
+		if r.matches(offset, date.Weekday(), start.Weekday()) {
+			if len(dates) == maxEventSessions {
+				return nil, ErrRecurrenceTooManySessions
+			}
+			dates = append(dates, date)
+		}
+	}
+	if len(dates) == 0 {
+		return nil, ErrRecurrenceNoSessions
+	}
+	return dates, nil
+}
+
+// matches tells whether the day offset days after the first session has
+// a session.
+func (r *RecurrenceRule) matches(offset int, weekday, startWeekday time.Weekday) bool {
+	if r.Frequency == RecurrenceDaily {
+		return offset%r.Interval == 0 && (len(r.Weekdays) == 0 || slices.Contains(r.Weekdays, weekday))
+	}
+
+	// Weeks start on Monday, as in iCalendar.
+	week := (offset + (int(startWeekday)+6)%7) / 7
+	if week%r.Interval != 0 {
+		return false
+	}
+	if len(r.Weekdays) == 0 {
+		return weekday == startWeekday
+	}
+	return slices.Contains(r.Weekdays, weekday)
+}
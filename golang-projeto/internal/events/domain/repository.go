@@ -1,12 +1,162 @@
 package domain
 
+import "time"
+
 type EventRepository interface {
-	ListEvents() ([]Event, error)
+	ListEvents(organizationId string) ([]Event, error)
 	FindEventById(eventId string) (*Event, error)
+	// FindOrganizationEvent is FindEventById restricted to the events of
+	// an organization; events of other organizations are not found.
+	FindOrganizationEvent(organizationId, eventId string) (*Event, error)
+	// FindEventSessions returns the sessions of a recurring event by
+	// date, without their spots and tickets.
+	FindEventSessions(eventId string) ([]*Event, error)
 	FindSpotsByEventId(eventId string) ([]*Spot, error)
 	FindSpotByName(eventId, spotName string) (*Spot, error)
-	// CreateEvent(event *Event) error
+	// CreateEvent inserts the event with its spots and sessions.
+	CreateEvent(event *Event) error
 	CreateSpot(spot *Spot) error
+	CreateSpots(spots []Spot) error
 	CreateTicket(ticket *Ticket) error
-	ReserveSpot(spotId, ticketId string) error
+	// ReserveSpot sells the spot unless it was sold or is held by a hold
+	// other than holdId in the meantime.
+	ReserveSpot(spot *Spot, holdId string) error
+	ClaimTickets(eventId string, quantity int) error
+	ReleaseTickets(eventId string, quantity int) error
+	// ClaimSalesPhaseTickets is ClaimTickets for the quota of a sales
+	// phase, failing with ErrSalesPhaseSoldOut.
+	ClaimSalesPhaseTickets(eventId string, kind SalesPhaseKind, quantity int) error
+	ReleaseSalesPhaseTickets(eventId string, kind SalesPhaseKind, quantity int) error
+	CreateHold(hold *Hold) error
+	FindHoldById(holdId string) (*Hold, error)
+	// ClaimHoldTickets moves quantity tickets of a general admission hold
+	// to a purchase, failing with ErrHoldExpired once the hold expired.
+	ClaimHoldTickets(holdId string, quantity int) error
+	ReleaseHoldTickets(holdId string, quantity int) error
+	FindExpiredHolds(at time.Time) ([]*Hold, error)
+	ReleaseHold(holdId string) error
+	FindTicketById(ticketId string) (*Ticket, error)
+	FindOrganizationTicket(organizationId, ticketId string) (*Ticket, error)
+	CancelTicket(ticket *Ticket) error
+	CheckInTicket(ticket *Ticket) error
+	// FindCheckInTickets returns the tickets of the event that have not
+	// entered yet, with their credential version.
+	FindCheckInTickets(eventId string) ([]CheckInManifestTicket, error)
+	CancelEvent(event *Event) error
+	FindActiveTicketsByEventId(eventId string) ([]Ticket, error)
+	FindEventsToRemind(from, to time.Time) ([]*Event, error)
+	MarkEventReminded(eventId string, at time.Time) error
+	FindPurchaseLimits(eventId string) (*PurchaseLimits, error)
+	SavePurchaseLimits(limits *PurchaseLimits) error
+	// SaveSalesPhases replaces the sales phases of the event, keeping the
+	// tickets sold in each of them.
+	SaveSalesPhases(event *Event) error
+}
+
+type OrderRepository interface {
+	CreateOrder(order *Order) error
+	FindOrderById(orderId string) (*Order, error)
+	UpdateOrderPayment(order *Order) error
+	// ClaimPurchase atomically checks the purchase limits against the
+	// buyer's history, purchases in progress included, and records the
+	// order as in progress until it is created or released.
+	ClaimPurchase(limits *PurchaseLimits, order *Order, quantity int) error
+	ReleasePurchase(orderId string) error
+	HasPaidHoldOrder(holdId, email string) (bool, error)
+}
+
+type NotificationRepository interface {
+	CreateNotification(notification *Notification) error
+	FindDueNotifications(at time.Time, limit int) ([]*Notification, error)
+	UpdateNotification(notification *Notification) error
+}
+
+type WaitlistRepository interface {
+	CreateWaitlistEntry(entry *WaitlistEntry) error
+	UpdateWaitlistEntry(entry *WaitlistEntry) error
+	FindWaitlistEntryByEmail(eventId, email string) (*WaitlistEntry, error)
+	FindNextWaitlistEntry(eventId string) (*WaitlistEntry, error)
+	FindExpiredWaitlistOffers(at time.Time) ([]*WaitlistEntry, error)
+}
+
+type TicketTransferRepository interface {
+	CreateTransfer(transfer *TicketTransfer) error
+	FindTransferByTokenHash(tokenHash string) (*TicketTransfer, error)
+	FindPendingTransferByTicketId(ticketId string) (*TicketTransfer, error)
+	UpdateTransfer(transfer *TicketTransfer) error
+	AcceptTransfer(transfer *TicketTransfer, ticket *Ticket) error
+	ExpireTransfers(at time.Time) (int, error)
+	FindTicketHolders(ticketId string) ([]TicketHolder, error)
+}
+
+type DomainEventRepository interface {
+	FindUnpublishedEvents(limit int) ([]DomainEvent, error)
+	MarkEventPublished(eventId string, at time.Time) error
+}
+
+type WebhookRepository interface {
+	CreateSubscription(subscription *WebhookSubscription) error
+	FindSubscriptionById(subscriptionId string) (*WebhookSubscription, error)
+	// FindOrganizationSubscription is FindSubscriptionById restricted to the
+	// subscriptions of an organization.
+	FindOrganizationSubscription(organizationId, subscriptionId string) (*WebhookSubscription, error)
+	// FindEventSubscriptions returns the active subscriptions of the
+	// organization of an event.
+	FindEventSubscriptions(eventId string) ([]*WebhookSubscription, error)
+	CreateDeliveries(deliveries []*WebhookDelivery) error
+	FindDeliveryById(deliveryId string) (*WebhookDelivery, error)
+	FindDeliveries(subscriptionId string, status WebhookDeliveryStatus, limit int) ([]*WebhookDelivery, error)
+	FindDueDeliveries(at time.Time, limit int) ([]*WebhookDelivery, error)
+	UpdateDelivery(delivery *WebhookDelivery) error
+}
+
+type WaitingRoomRepository interface {
+	SaveWaitingRoom(room *WaitingRoom) error
+	FindWaitingRoom(eventId string) (*WaitingRoom, error)
+	FindOrganizationWaitingRoom(organizationId, eventId string) (*WaitingRoom, error)
+	FindActiveWaitingRooms() ([]*WaitingRoom, error)
+	CreateWaitingRoomEntry(entry *WaitingRoomEntry) error
+	FindWaitingRoomEntry(token string) (*WaitingRoomEntry, error)
+	CountWaitingAhead(eventId string, seq int64) (int, error)
+	CountWaiting(eventId string) (int, error)
+	// AdmitWaitingRoomEntries admits up to due waiting entries in FIFO order
+	// and stores the room admission clock, returning how many were admitted.
+	AdmitWaitingRoomEntries(room *WaitingRoom, due int, at time.Time) (int, error)
+	// ConsumeWaitingRoomEntry stores a consumed entry unless its token was
+	// used or bound to another customer in the meantime, failing with
+	// ErrAdmissionTokenUsed.
+	ConsumeWaitingRoomEntry(entry *WaitingRoomEntry) error
+	// RestoreWaitingRoomEntry admits a consumed entry again after its
+	// checkout failed, keeping it bound to the same customer.
+	RestoreWaitingRoomEntry(token string) error
+}
+
+type CustomerRepository interface {
+	CreateCustomer(customer *Customer) error
+	UpdateCustomer(customer *Customer) error
+	FindCustomerByUserId(userId string) (*Customer, error)
+	// FindCustomerTickets returns the tickets held by the customer, with
+	// their events, including cancelled and used ones.
+	FindCustomerTickets(customer *Customer) ([]CustomerTicket, error)
+	// FindCustomerOrders returns the orders of the customer with their
+	// tickets and events, newest first.
+	FindCustomerOrders(customer *Customer) ([]CustomerOrder, error)
+}
+
+type OrganizationRepository interface {
+	FindOrganizationById(organizationId string) (*Organization, error)
+	FindOrganizationBySlug(slug string) (*Organization, error)
+	FindOrganizationByHost(host string) (*Organization, error)
+}
+
+type VenueRepository interface {
+	CreateVenue(venue *Venue) error
+	CreateVenueLayout(venueId string, layout *VenueLayout) error
+	// FindOrganizationVenue returns a venue of an organization with its
+	// layouts; venues of other organizations are not found.
+	FindOrganizationVenue(organizationId, venueId string) (*Venue, error)
+	ListVenues(organizationId string) ([]*Venue, error)
+	// FindUpcomingVenueEvents returns the events at the venue starting
+	// after from, soonest first, without spots and tickets.
+	FindUpcomingVenueEvents(venueId string, from time.Time) ([]*Event, error)
 }
@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SalesPhaseKind identifies a sales phase. An event has at most one phase
+// of each kind.
+type SalesPhaseKind string
+
+const (
+	SalesPhasePresale SalesPhaseKind = "presale"
+	SalesPhaseGeneral SalesPhaseKind = "general"
+	SalesPhaseDoor    SalesPhaseKind = "door"
+)
+
+var (
+	ErrSalesPhaseInvalidKind         = errors.New("sales phase kind must be presale, general or door")
+	ErrSalesPhaseDuplicated          = errors.New("event already has a sales phase of this kind")
+	ErrSalesPhaseInvalidPeriod       = errors.New("sales phase must start before it ends")
+	ErrSalesPhaseOverlap             = errors.New("sales phases must not overlap")
+	ErrSalesPhaseInvalidPrice        = errors.New("sales phase price must not be negative")
+	ErrSalesPhaseOutsideSalesWindow  = errors.New("sales phase never opens within the event sales window")
+	ErrSalesPhaseInvalidQuota        = errors.New("sales phase quota must not be negative nor below the tickets already sold")
+	ErrSalesPhaseAccessCodeMissing   = errors.New("presale requires an access code")
+	ErrSalesPhaseNotOpen             = errors.New("no sales phase is open for this event")
+	ErrSalesPhaseAccessCodeRequired  = errors.New("an access code is required during the presale")
+	ErrSalesPhaseInvalidAccessCode   = errors.New("invalid presale access code")
+	ErrSalesPhaseSoldOut             = errors.New("not enough tickets left in the current sales phase")
+	ErrSalesPhaseAccessCodeForbidden = errors.New("only the presale takes an access code")
+)
+
+// SalesPhase is a period when tickets of an event are sold at Price, up to
+// Quota tickets (zero for no quota besides the event capacity). Tickets of
+// the presale require its access code, of which only a hash is kept.
+type SalesPhase struct {
+	EventId        string
+	Kind           SalesPhaseKind
+	StartsAt       time.Time
+	EndsAt         time.Time
+	Price          float64
+	Quota          int
+	SoldTickets    int
+	AccessCodeHash string
+}
+
+func (p *SalesPhase) Validate() error {
+	if p.Kind != SalesPhasePresale && p.Kind != SalesPhaseGeneral && p.Kind != SalesPhaseDoor {
+		return ErrSalesPhaseInvalidKind
+	}
+	if !p.StartsAt.Before(p.EndsAt) {
+		return ErrSalesPhaseInvalidPeriod
+	}
+	if p.Price < 0 {
+		return ErrSalesPhaseInvalidPrice
+	}
+	if p.Quota < 0 || (p.Quota > 0 && p.Quota < p.SoldTickets) {
+		return ErrSalesPhaseInvalidQuota
+	}
+	if p.Kind == SalesPhasePresale && p.AccessCodeHash == "" {
+		return ErrSalesPhaseAccessCodeMissing
+	}
+	if p.Kind != SalesPhasePresale && p.AccessCodeHash != "" {
+		return ErrSalesPhaseAccessCodeForbidden
+	}
+	return nil
+}
+
+// HashAccessCode returns the stored form of a presale access code. Codes
+// are compared case-insensitively.
+func HashAccessCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsOpen tells whether tickets are sold in the phase at the given time.
+func (p *SalesPhase) IsOpen(at time.Time) bool {
+	return !at.Before(p.StartsAt) && at.Before(p.EndsAt)
+}
+
+// CanSell checks the access code and whether quantity more tickets fit in
+// the quota of the phase.
+func (p *SalesPhase) CanSell(quantity int, accessCode string) error {
+	if p.AccessCodeHash != "" {
+		if strings.TrimSpace(accessCode) == "" {
+			return ErrSalesPhaseAccessCodeRequired
+		}
+		if subtle.ConstantTimeCompare([]byte(HashAccessCode(accessCode)), []byte(p.AccessCodeHash)) != 1 {
+			return ErrSalesPhaseInvalidAccessCode
+		}
+	}
+	if p.Quota > 0 && p.SoldTickets+quantity > p.Quota {
+		return ErrSalesPhaseSoldOut
+	}
+	return nil
+}
+
+// SetSalesPhases replaces the sales phases of the event. Phases keep the
+// tickets already sold in the phase of the same kind, and the presale its
+// access code when no new one is given. The sales window of the event is
+// left as is: tickets are only sold while both the window and a phase are
+// open, except that the last phase may run past the end of the window, so
+// door sales go on after online sales close. Phases that would never open
+// are refused.
+func (e *Event) SetSalesPhases(phases []SalesPhase) error {
+	if e.HasSessions() {
+		return ErrEventHasSessions
+	}
+
+	kinds := make(map[SalesPhaseKind]bool, len(phases))
+	phases = slices.Clone(phases)
+	for i := range phases {
+		phase := &phases[i]
+		if kinds[phase.Kind] {
+			return ErrSalesPhaseDuplicated
+		}
+		kinds[phase.Kind] = true
+
+		phase.EventId = e.Id
+		if current := e.SalesPhase(phase.Kind); current != nil {
+			phase.SoldTickets = current.SoldTickets
+			if phase.AccessCodeHash == "" {
+				phase.AccessCodeHash = current.AccessCodeHash
+			}
+		}
+		if err := phase.Validate(); err != nil {
+			return err
+		}
+	}
+
+	slices.SortFunc(phases, func(a, b SalesPhase) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+	for i := 1; i < len(phases); i++ {
+		if phases[i].StartsAt.Before(phases[i-1].EndsAt) {
+			return ErrSalesPhaseOverlap
+		}
+	}
+	for i, phase := range phases {
+		if !e.SalesStartAt.IsZero() && !phase.EndsAt.After(e.SalesStartAt) {
+			return ErrSalesPhaseOutsideSalesWindow
+		}
+		last := i == len(phases)-1
+		if !last && !e.SalesEndAt.IsZero() && !phase.StartsAt.Before(e.SalesEndAt) {
+			return ErrSalesPhaseOutsideSalesWindow
+		}
+	}
+
+	e.SalesPhases = phases
+	return nil
+}
+
+// SalesPhase returns the phase of the given kind, if the event has one.
+func (e *Event) SalesPhase(kind SalesPhaseKind) *SalesPhase {
+	for i := range e.SalesPhases {
+		if e.SalesPhases[i].Kind == kind {
+			return &e.SalesPhases[i]
+		}
+	}
+	return nil
+}
+
+// CurrentSalesPhase returns the phase open at the given time, if any.
+func (e *Event) CurrentSalesPhase(at time.Time) *SalesPhase {
+	for i := range e.SalesPhases {
+		if e.SalesPhases[i].IsOpen(at) {
+			return &e.SalesPhases[i]
+		}
+	}
+	return nil
+}
+
+// NextSalesPhase returns the first phase starting after the given time, if
+// any.
+func (e *Event) NextSalesPhase(at time.Time) *SalesPhase {
+	for i := range e.SalesPhases {
+		if e.SalesPhases[i].StartsAt.After(at) {
+			return &e.SalesPhases[i]
+		}
+	}
+	return nil
+}
+
+// SellInSalesPhase checks that quantity tickets can be sold at the given
+// time in the open phase of the event and returns it with the price of a
+// full ticket in it. The access code is only checked during the presale.
+// Events without phases are sold at their own price and return no phase.
+func (e *Event) SellInSalesPhase(at time.Time, quantity int, accessCode string) (*SalesPhase, float64, error) {
+	if len(e.SalesPhases) == 0 {
+		return nil, e.Price, nil
+	}
+
+	phase := e.CurrentSalesPhase(at)
+	if phase == nil {
+		return nil, 0, ErrSalesPhaseNotOpen
+	}
+	if err := phase.CanSell(quantity, accessCode); err != nil {
+		return nil, 0, err
+	}
+	return phase, phase.Price, nil
+}
+
+// inLastSalesPhase tells whether the last phase of the event is open at
+// the given time.
+func (e *Event) inLastSalesPhase(at time.Time) bool {
+	return len(e.SalesPhases) > 0 && e.SalesPhases[len(e.SalesPhases)-1].IsOpen(at)
+}
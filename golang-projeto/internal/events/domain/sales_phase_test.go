@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var phaseBase = time.Date(2030, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func day(n int) time.Time {
+	return phaseBase.AddDate(0, 0, n)
+}
+
+func presale(starts, ends int, price float64) SalesPhase {
+	return SalesPhase{Kind: SalesPhasePresale, StartsAt: day(starts), EndsAt: day(ends), Price: price, AccessCodeHash: HashAccessCode("fans")}
+}
+
+func general(starts, ends int, price float64) SalesPhase {
+	return SalesPhase{Kind: SalesPhaseGeneral, StartsAt: day(starts), EndsAt: day(ends), Price: price}
+}
+
+func door(starts, ends int, price float64) SalesPhase {
+	return SalesPhase{Kind: SalesPhaseDoor, StartsAt: day(starts), EndsAt: day(ends), Price: price}
+}
+
+func TestSalesPhaseValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase SalesPhase
+		err   error
+	}{
+		{name: "valid", phase: general(0, 1, 50)},
+		{name: "free", phase: general(0, 1, 0)},
+		{name: "negative price", phase: general(0, 1, -1), err: ErrSalesPhaseInvalidPrice},
+		{name: "invalid kind", phase: SalesPhase{Kind: "vip", StartsAt: day(0), EndsAt: day(1)}, err: ErrSalesPhaseInvalidKind},
+		{name: "empty period", phase: general(1, 1, 50), err: ErrSalesPhaseInvalidPeriod},
+		{name: "quota below sold", phase: SalesPhase{Kind: SalesPhaseGeneral, StartsAt: day(0), EndsAt: day(1), Quota: 5, SoldTickets: 6}, err: ErrSalesPhaseInvalidQuota},
+		{name: "presale without access code", phase: SalesPhase{Kind: SalesPhasePresale, StartsAt: day(0), EndsAt: day(1)}, err: ErrSalesPhaseAccessCodeMissing},
+		{name: "access code outside presale", phase: SalesPhase{Kind: SalesPhaseDoor, StartsAt: day(0), EndsAt: day(1), AccessCodeHash: HashAccessCode("fans")}, err: ErrSalesPhaseAccessCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.phase.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEventSetSalesPhases(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases []SalesPhase
+		err    error
+	}{
+		{name: "consecutive phases", phases: []SalesPhase{presale(0, 2, 40), general(2, 9, 50)}},
+		{name: "door phase after the window", phases: []SalesPhase{general(0, 10, 50), door(10, 11, 60)}},
+		{name: "overlap", phases: []SalesPhase{presale(0, 3, 40), general(2, 9, 50)}, err: ErrSalesPhaseOverlap},
+		{name: "duplicated kind", phases: []SalesPhase{general(0, 1, 50), general(2, 3, 50)}, err: ErrSalesPhaseDuplicated},
+		{name: "ends before the window", phases: []SalesPhase{presale(-3, 0, 40), general(0, 9, 50)}, err: ErrSalesPhaseOutsideSalesWindow},
+		{name: "starts after the window", phases: []SalesPhase{general(10, 11, 50), door(11, 12, 60)}, err: ErrSalesPhaseOutsideSalesWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{Id: "event", Price: 30, SalesStartAt: day(0), SalesEndAt: day(10)}
+			err := event.SetSalesPhases(tt.phases)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SetSalesPhases() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && len(event.SalesPhases) != len(tt.phases) {
+				t.Errorf("SetSalesPhases() kept %d phases, want %d", len(event.SalesPhases), len(tt.phases))
+			}
+		})
+	}
+}
+
+func TestEventSellInSalesPhase(t *testing.T) {
+	event := &Event{Id: "event", Price: 30, SalesStartAt: day(0), SalesEndAt: day(10)}
+	phases := []SalesPhase{presale(0, 2, 40), general(2, 10, 0), door(10, 11, 60)}
+	phases[1].Quota = 10
+	phases[1].SoldTickets = 8
+	if err := event.SetSalesPhases(phases); err != nil {
+		t.Fatalf("SetSalesPhases() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		quantity   int
+		accessCode string
+		kind       SalesPhaseKind
+		price      float64
+		err        error
+	}{
+		{name: "presale", at: day(1), quantity: 1, accessCode: " Fans ", kind: SalesPhasePresale, price: 40},
+		{name: "presale without code", at: day(1), quantity: 1, err: ErrSalesPhaseAccessCodeRequired},
+		{name: "presale with wrong code", at: day(1), quantity: 1, accessCode: "other", err: ErrSalesPhaseInvalidAccessCode},
+		{name: "free general sale", at: day(2), quantity: 2, kind: SalesPhaseGeneral, price: 0},
+		{name: "general sale over quota", at: day(3), quantity: 3, err: ErrSalesPhaseSoldOut},
+		{name: "door", at: day(10), quantity: 1, kind: SalesPhaseDoor, price: 60},
+		{name: "after the last phase", at: day(11), quantity: 1, err: ErrSalesPhaseNotOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, price, err := event.SellInSalesPhase(tt.at, tt.quantity, tt.accessCode)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SellInSalesPhase() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if phase.Kind != tt.kind || price != tt.price {
+				t.Errorf("SellInSalesPhase() = %s, %v, want %s, %v", phase.Kind, price, tt.kind, tt.price)
+			}
+			if event.Price != 30 {
+				t.Errorf("SellInSalesPhase() changed the event price to %v", event.Price)
+			}
+		})
+	}
+}
+
+func TestEventSellInSalesPhaseWithoutPhases(t *testing.T) {
+	event := &Event{Price: 30}
+	phase, price, err := event.SellInSalesPhase(day(0), 1, "")
+	if err != nil || phase != nil || price != 30 {
+		t.Errorf("SellInSalesPhase() = %v, %v, %v, want nil, 30, nil", phase, price, err)
+	}
+}
+
+func TestEventCheckSalesWindowWithDoorPhase(t *testing.T) {
+	event := &Event{Id: "event", SalesStartAt: day(0), SalesEndAt: day(10)}
+	if err := event.SetSalesPhases([]SalesPhase{general(0, 9, 50), door(10, 11, 60)}); err != nil {
+		t.Fatalf("SetSalesPhases() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		err  error
+	}{
+		{name: "before the window", at: day(-1), err: ErrEventSalesNotStarted},
+		{name: "within the window", at: day(5)},
+		{name: "door phase past the window", at: day(10).Add(time.Hour)},
+		{name: "after the door phase", at: day(11), err: ErrEventSalesEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := event.CheckSalesWindow(tt.at); !errors.Is(err, tt.err) {
+				t.Errorf("CheckSalesWindow() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	// stageDistanceWeight is the score added per row away from the stage.
+	stageDistanceWeight = 2.0
+	// centerDistanceWeight is the score added per seat away from the row center.
+	centerDistanceWeight = 1.0
+)
+
+// SeatSelectionService picks the best group of spots for a customer who
+// did not choose seats by name.
+type SeatSelectionService struct{}
+
+func NewSeatSelectionService() *SeatSelectionService {
+	return &SeatSelectionService{}
+}
+
+var (
+	ErrSeatSelectionInvalidQuantity = errors.New("quantity must be greater than zero")
+	ErrSeatSelectionNotAvailable    = errors.New("no contiguous group of available spots found")
+)
+
+type seatCandidate struct {
+	spot   *Spot
+	number int
+}
+
+// BestAvailable returns quantity spots available at the given time which
+// sit next to each other in the same row, optionally restricted to zone.
+// Spots with any attribute (accessible, obstructed or restricted) are never
+// picked automatically.
+//
+// Row A is taken as the closest to the stage. Among all contiguous groups,
+// the one with the lowest score wins, where the score grows with the
+// distance to the stage and with the distance between the group center and
+// the row center.
+func (s *SeatSelectionService) BestAvailable(spots []*Spot, quantity int, zone string, at time.Time) ([]*Spot, error) {
+	if quantity <= 0 {
+		return nil, ErrSeatSelectionInvalidQuantity
+	}
+
+	rows := make(map[string][]seatCandidate)
+	bounds := make(map[string][2]int)
+	for _, spot := range spots {
+		row, number, err := ParseSpotName(spot.Name)
+		if err != nil {
+			continue
+		}
+
+		b, ok := bounds[row]
+		if !ok {
+			b = [2]int{number, number}
+		}
+		bounds[row] = [2]int{min(b[0], number), max(b[1], number)}
+
+		if zone != "" && spot.Zone != zone {
+			continue
+		}
+		if !spot.IsAvailable(at) || len(spot.Attributes) > 0 {
+			continue
+		}
+		rows[row] = append(rows[row], seatCandidate{spot: spot, number: number})
+	}
+
+	var best []seatCandidate
+	bestScore, bestRow := math.Inf(1), 0
+	for row, candidates := range rows {
+		rowIndex, _ := RowIndex(row)
+		rowCenter := float64(bounds[row][0]+bounds[row][1]) / 2
+
+		slices.SortFunc(candidates, func(a, b seatCandidate) int { return a.number - b.number })
+		for i := 0; i+quantity <= len(candidates); i++ {
+			group := candidates[i : i+quantity]
+			if group[quantity-1].number-group[0].number != quantity-1 {
+				continue
+			}
+
+			groupCenter := float64(group[0].number+group[quantity-1].number) / 2
+			score := stageDistanceWeight*float64(rowIndex) + centerDistanceWeight*math.Abs(groupCenter-rowCenter)
+			if score < bestScore || (score == bestScore && rowIndex < bestRow) {
+				best, bestScore, bestRow = group, score, rowIndex
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, ErrSeatSelectionNotAvailable
+	}
+
+	selected := make([]*Spot, len(best))
+	for i, candidate := range best {
+		selected[i] = candidate.spot
+	}
+	return selected, nil
+}
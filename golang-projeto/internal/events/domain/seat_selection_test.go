@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// seatMap builds the spots of rows × seats, all available, in zone "main".
+func seatMap(rows, seats int) []*Spot {
+	var spots []*Spot
+	for row := range rows {
+		for seat := 1; seat <= seats; seat++ {
+			spots = append(spots, &Spot{
+				Name:   RowLabel(row) + strconv.Itoa(seat),
+				Zone:   "main",
+				Status: SpotStatusAvailable,
+			})
+		}
+	}
+	return spots
+}
+
+func spotNamed(spots []*Spot, name string) *Spot {
+	for _, spot := range spots {
+		if spot.Name == name {
+			return spot
+		}
+	}
+	return nil
+}
+
+func spotNames(spots []*Spot) []string {
+	names := make([]string, len(spots))
+	for i, spot := range spots {
+		names[i] = spot.Name
+	}
+	return names
+}
+
+func TestBestAvailable(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		spots    func() []*Spot
+		quantity int
+		zone     string
+		want     []string
+		err      error
+	}{
+		{
+			name:     "front row center",
+			spots:    func() []*Spot { return seatMap(3, 5) },
+			quantity: 3,
+			want:     []string{"A2", "A3", "A4"},
+		},
+		{
+			name: "skips sold spots",
+			spots: func() []*Spot {
+				spots := seatMap(2, 5)
+				spotNamed(spots, "A3").Status = SpotStatusSold
+				return spots
+			},
+			quantity: 3,
+			want:     []string{"B2", "B3", "B4"},
+		},
+		{
+			name: "takes expired holds",
+			spots: func() []*Spot {
+				spots := seatMap(1, 3)
+				spot := spotNamed(spots, "A2")
+				spot.Status = SpotStatusHeld
+				spot.HoldExpiresAt = now.Add(-time.Minute)
+				return spots
+			},
+			quantity: 3,
+			want:     []string{"A1", "A2", "A3"},
+		},
+		{
+			name: "never picks spots with attributes",
+			spots: func() []*Spot {
+				spots := seatMap(2, 3)
+				spotNamed(spots, "A2").Attributes = []SpotAttribute{SpotAttributeWheelchair}
+				return spots
+			},
+			quantity: 2,
+			want:     []string{"B1", "B2"},
+		},
+		{
+			name: "restricted to zone",
+			spots: func() []*Spot {
+				spots := seatMap(2, 3)
+				for _, spot := range spots[3:] {
+					spot.Zone = "balcony"
+				}
+				return spots
+			},
+			quantity: 1,
+			zone:     "balcony",
+			want:     []string{"B2"},
+		},
+		{
+			name: "needs contiguous seats",
+			spots: func() []*Spot {
+				spots := seatMap(1, 4)
+				spotNamed(spots, "A2").Status = SpotStatusSold
+				return spots
+			},
+			quantity: 3,
+			err:      ErrSeatSelectionNotAvailable,
+		},
+		{
+			name:     "invalid quantity",
+			spots:    func() []*Spot { return seatMap(1, 1) },
+			quantity: 0,
+			err:      ErrSeatSelectionInvalidQuantity,
+		},
+	}
+
+	service := NewSeatSelectionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := service.BestAvailable(tt.spots(), tt.quantity, tt.zone, now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("BestAvailable() error = %v, want %v", err, tt.err)
+			}
+			if names := spotNames(selected); !slices.Equal(names, tt.want) {
+				t.Errorf("BestAvailable() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
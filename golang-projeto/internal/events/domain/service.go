@@ -1,9 +1,6 @@
 package domain
 
-import (
-	"errors"
-	"fmt"
-)
+import "slices"
 
 type SpotService struct{}
 
@@ -11,23 +8,58 @@ func NewSpotService() *SpotService {
 	return &SpotService{}
 }
 
-var (
-	ErrorSpotServiceInvalidQuantity = errors.New("quantity must be greater than zero")
-)
+// GenerateSpots creates the spots described by layout, appends them to the
+// event and returns only the newly created ones. The event must be seated,
+// without sessions, and the total number of spots can never exceed its
+// capacity.
+func (s *SpotService) GenerateSpots(event *Event, layout SpotLayout) ([]Spot, error) {
+	if event.HasSessions() {
+		return nil, ErrEventHasSessions
+	}
+	if event.IsGeneralAdmission() {
+		return nil, ErrEventGeneralAdmission
+	}
+
+	count, err := layout.SpotCount()
+	if err != nil {
+		return nil, err
+	}
+	if len(event.Spots)+count > event.Capacity {
+		return nil, ErrEventCapacityExceeded
+	}
+	names, err := layout.SpotNames()
+	if err != nil {
+		return nil, err
+	}
+	for name := range layout.Attributes {
+		if !slices.Contains(names, name) {
+			return nil, ErrSpotLayoutUnknownSpot
+		}
+	}
 
-func (s *SpotService) GenerateSports(event *Event, quantity int) error {
-	if quantity <= 0 {
-		return ErrorSpotServiceInvalidQuantity
+	existing := make(map[string]bool, len(event.Spots))
+	for _, spot := range event.Spots {
+		existing[spot.Name] = true
 	}
 
-	for i := range quantity {
-		spotName := fmt.Sprintf("%c%d", 'A'+i/10, i%10+1)
-		spot, err := NewSpot(event, spotName)
+	spots := make([]Spot, 0, len(names))
+	for _, name := range names {
+		if existing[name] {
+			return nil, ErrSpotAlreadyExists
+		}
+		spot, err := NewSpot(event, name)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		row, _, _ := ParseSpotName(name)
+		spot.Zone = layout.ZoneOf(row)
+		spot.Attributes = layout.Attributes[name]
+		if err := spot.Validate(); err != nil {
+			return nil, err
 		}
-		event.Spots = append(event.Spots, *spot)
+		spots = append(spots, *spot)
 	}
 
-	return nil
+	event.Spots = append(event.Spots, spots...)
+	return spots, nil
 }
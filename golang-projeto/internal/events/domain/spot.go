@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,14 +12,21 @@ type SpotStatus string
 const (
 	SpotStatusAvailable SpotStatus = "available"
 	SpotStatusSold      SpotStatus = "sold"
+	SpotStatusHeld      SpotStatus = "held"
 )
 
 type Spot struct {
-	Id       string
-	EventId  string
-	Name     string
-	Status   SpotStatus
-	TicketId string
+	Id            string
+	EventId       string
+	Name          string
+	Zone          string
+	Attributes    []SpotAttribute
+	Status        SpotStatus
+	TicketId      string
+	HoldId        string
+	HoldExpiresAt time.Time
+
+	domainEvents
 }
 
 var (
@@ -27,22 +35,22 @@ var (
 	ErrInvalidSpotNameFirstCharacter = errors.New("spot name must start with a letter")
 	ErrInvalidSpotNameLastCharacter  = errors.New("spot name must end with a number")
 	ErrInvalidSpotNumber             = errors.New("invalid spot number")
+	ErrInvalidSpotRowLabel           = errors.New("spot row label must have between 1 and 3 letters")
 	ErrSpotNotFound                  = errors.New("spot not found")
 	ErrSpotAlreadyReserved           = errors.New("spot already reserved")
+	ErrSpotAlreadyExists             = errors.New("spot already exists")
+	ErrSpotDuplicated                = errors.New("spot listed more than once")
+	ErrSpotHeld                      = errors.New("spot is held by another customer")
 )
 
 func (s *Spot) Validate() error {
-	if s.Name == "" {
-		return ErrSpotNameRequired
-	}
-	if len(s.Name) < 2 {
-		return ErrSpotNameLessThanTwo
-	}
-	if s.Name[0] < 'A' || s.Name[0] > 'Z' {
-		return ErrInvalidSpotNameFirstCharacter
+	if _, _, err := ParseSpotName(s.Name); err != nil {
+		return err
 	}
-	if s.Name[1] < '0' || s.Name[1] > '9' {
-		return ErrInvalidSpotNameLastCharacter
+	for _, attribute := range s.Attributes {
+		if !IsValidSpotAttribute(attribute) {
+			return ErrInvalidSpotAttribute
+		}
 	}
 	return nil
 }
@@ -61,12 +69,76 @@ func NewSpot(event *Event, name string) (*Spot, error) {
 	return spot, nil
 }
 
-func (s *Spot) Reserve(ticketId string) error {
+// IsAvailable reports whether the spot can be held or sold at the given
+// time. A spot whose hold has expired is available again.
+func (s *Spot) IsAvailable(at time.Time) bool {
+	switch s.Status {
+	case SpotStatusAvailable:
+		return true
+	case SpotStatusHeld:
+		return !at.Before(s.HoldExpiresAt)
+	default:
+		return false
+	}
+}
+
+// Hold marks the spot as held until expiresAt.
+func (s *Spot) Hold(holdId string, expiresAt time.Time) error {
 	if s.Status == SpotStatusSold {
 		return ErrSpotAlreadyReserved
 	}
+	if !s.IsAvailable(time.Now()) {
+		return ErrSpotHeld
+	}
+
+	s.Status = SpotStatusHeld
+	s.HoldId = holdId
+	s.HoldExpiresAt = expiresAt
+	s.record(DomainEventSpotHeld, s.Id, s.EventId, map[string]any{
+		"spot":       s.Name,
+		"hold_id":    holdId,
+		"expires_at": expiresAt.UTC(),
+	})
+	return nil
+}
+
+// Release makes a held spot available again.
+func (s *Spot) Release() {
+	if s.Status != SpotStatusHeld {
+		return
+	}
+
+	s.Status = SpotStatusAvailable
+	s.HoldId = ""
+	s.HoldExpiresAt = time.Time{}
+}
+
+// CanBeReservedBy checks whether the holder of holdId (or anyone, when
+// holdId is empty) may buy the spot right now.
+func (s *Spot) CanBeReservedBy(holdId string) error {
+	if s.Status == SpotStatusSold {
+		return ErrSpotAlreadyReserved
+	}
+	if !s.IsAvailable(time.Now()) && s.HoldId != holdId {
+		return ErrSpotHeld
+	}
+	return nil
+}
+
+// Reserve sells the spot. A spot under an active hold can only be reserved
+// by the holder, identified by holdId.
+func (s *Spot) Reserve(ticketId, holdId string) error {
+	if err := s.CanBeReservedBy(holdId); err != nil {
+		return err
+	}
 
 	s.Status = SpotStatusSold
 	s.TicketId = ticketId
+	s.HoldId = ""
+	s.HoldExpiresAt = time.Time{}
+	s.record(DomainEventSpotReserved, s.Id, s.EventId, map[string]any{
+		"spot":      s.Name,
+		"ticket_id": ticketId,
+	})
 	return nil
 }
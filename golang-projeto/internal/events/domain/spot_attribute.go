@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+// SpotAttribute flags a spot as different from a regular seat.
+type SpotAttribute string
+
+const (
+	SpotAttributeWheelchair     SpotAttribute = "wheelchair"
+	SpotAttributeCompanion      SpotAttribute = "companion"
+	SpotAttributeObstructedView SpotAttribute = "obstructed_view"
+	SpotAttributeRestricted     SpotAttribute = "restricted"
+)
+
+var (
+	ErrInvalidSpotAttribute           = errors.New("invalid spot attribute")
+	ErrSpotRestricted                 = errors.New("spot is restricted and cannot be sold")
+	ErrCompanionSeatWithoutWheelchair = errors.New("companion seats can only be purchased together with a wheelchair space")
+	ErrObstructedViewNotAccepted      = errors.New("spots with obstructed view must be explicitly accepted")
+)
+
+func IsValidSpotAttribute(attribute SpotAttribute) bool {
+	switch attribute {
+	case SpotAttributeWheelchair, SpotAttributeCompanion, SpotAttributeObstructedView, SpotAttributeRestricted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Spot) HasAttribute(attribute SpotAttribute) bool {
+	return slices.Contains(s.Attributes, attribute)
+}
+
+// ValidateSpotSelection enforces the eligibility rules for buying a set of
+// spots in a single order:
+//   - restricted spots are never sold;
+//   - each companion seat requires a wheelchair space in the same order;
+//   - obstructed view spots require the buyer to accept the limitation.
+func ValidateSpotSelection(spots []*Spot, acceptObstructedView bool) error {
+	wheelchairs, companions := 0, 0
+	for _, spot := range spots {
+		if spot.HasAttribute(SpotAttributeRestricted) {
+			return ErrSpotRestricted
+		}
+		if spot.HasAttribute(SpotAttributeObstructedView) && !acceptObstructedView {
+			return ErrObstructedViewNotAccepted
+		}
+		if spot.HasAttribute(SpotAttributeWheelchair) {
+			wheelchairs++
+		}
+		if spot.HasAttribute(SpotAttributeCompanion) {
+			companions++
+		}
+	}
+
+	if companions > wheelchairs {
+		return ErrCompanionSeatWithoutWheelchair
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+)
+
+type SpotChangeKind string
+
+const (
+	SpotChangeSold     SpotChangeKind = "sold"
+	SpotChangeHeld     SpotChangeKind = "held"
+	SpotChangeReleased SpotChangeKind = "released"
+)
+
+// SpotChange tells live clients that a spot changed status. Seq orders the
+// changes of one event and is set by the hub when published.
+type SpotChange struct {
+	Seq           uint64
+	EventId       string
+	SpotId        string
+	SpotName      string
+	Zone          string
+	Kind          SpotChangeKind
+	HoldExpiresAt time.Time
+	At            time.Time
+}
+
+func NewSpotChange(spot *Spot, kind SpotChangeKind) SpotChange {
+	change := SpotChange{
+		EventId:  spot.EventId,
+		SpotId:   spot.Id,
+		SpotName: spot.Name,
+		Zone:     spot.Zone,
+		Kind:     kind,
+		At:       time.Now(),
+	}
+	if kind == SpotChangeHeld {
+		change.HoldExpiresAt = spot.HoldExpiresAt
+	}
+	return change
+}
+
+// SpotChanges builds one change of the given kind per spot.
+func SpotChanges(spots []*Spot, kind SpotChangeKind) []SpotChange {
+	changes := make([]SpotChange, len(spots))
+	for i, spot := range spots {
+		changes[i] = NewSpotChange(spot, kind)
+	}
+	return changes
+}
+
+// SpotAvailabilityHub broadcasts spot changes to the clients watching an
+// event. Delivery is best effort: changes are not persisted, and clients
+// missing too many of them must reload the spots.
+type SpotAvailabilityHub interface {
+	Publish(eventId string, changes ...SpotChange)
+	// Subscribe starts watching an event. Changes after lastSeq still kept by
+	// the hub are returned as Missed.
+	Subscribe(eventId string, lastSeq uint64) *SpotSubscription
+}
+
+// SpotSubscription is a client watching the spots of an event. Changes is
+// closed when the client falls too far behind, or after Close. Reset is set
+// when changes after the requested sequence were lost, so the client must
+// reload the spots before applying Changes.
+type SpotSubscription struct {
+	Missed  []SpotChange
+	Reset   bool
+	Changes <-chan SpotChange
+	Close   func()
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxRowLabelLength limits row labels to "ZZZ", i.e. 18278 rows.
+const MaxRowLabelLength = 3
+
+// MaxLayoutSpots bounds the seats of a layout, counting skipped ones, so
+// that a single layout cannot exhaust memory when its spots are generated.
+const MaxLayoutSpots = 100000
+
+// SpotLayout describes a seating plan as rows × seats-per-row.
+//
+// Rows are labelled A, B, ..., Z, AA, AB, ... skipping any label listed in
+// SkipRows (e.g. "I" and "O", which are easily mistaken for numbers).
+// Seat positions listed in SkipSeats are not created in any row, but their
+// number is still consumed. Aisles lists seat positions followed by an
+// aisle; the numbering jumps by one after each of them so that seats on
+// opposite sides of an aisle are never numbered consecutively. Zones group
+// consecutive rows under a name (e.g. "orchestra", "balcony"). Attributes
+// flags individual spots, keyed by spot name.
+type SpotLayout struct {
+	Rows        int
+	SeatsPerRow int
+	SkipRows    []string
+	SkipSeats   []int
+	Aisles      []int
+	Zones       []LayoutZone
+	Attributes  map[string][]SpotAttribute
+}
+
+// LayoutZone covers the rows from FirstRow to LastRow, inclusive.
+type LayoutZone struct {
+	Name     string
+	FirstRow string
+	LastRow  string
+}
+
+var (
+	ErrSpotLayoutInvalidRows        = errors.New("layout rows must be greater than zero")
+	ErrSpotLayoutInvalidSeatsPerRow = errors.New("layout seats per row must be greater than zero")
+	ErrSpotLayoutTooManyRows        = errors.New("layout has more rows than available row labels")
+	ErrSpotLayoutTooManySpots       = errors.New("layout has more than 100000 seats")
+	ErrSpotLayoutInvalidSkipRow     = errors.New("layout skip rows must be valid row labels")
+	ErrSpotLayoutInvalidSeat        = errors.New("layout seat positions must be between 1 and seats per row")
+	ErrSpotLayoutInvalidZone        = errors.New("layout zones must have a name and a valid row range")
+	ErrSpotLayoutUnknownSpot        = errors.New("layout attributes must reference spots of the layout")
+)
+
+func (l *SpotLayout) Validate() error {
+	if l.Rows <= 0 {
+		return ErrSpotLayoutInvalidRows
+	}
+	if l.SeatsPerRow <= 0 {
+		return ErrSpotLayoutInvalidSeatsPerRow
+	}
+	if l.Rows > MaxLayoutSpots/l.SeatsPerRow {
+		return ErrSpotLayoutTooManySpots
+	}
+	for _, label := range l.SkipRows {
+		if _, err := RowIndex(label); err != nil {
+			return ErrSpotLayoutInvalidSkipRow
+		}
+	}
+	for _, seat := range slices.Concat(l.SkipSeats, l.Aisles) {
+		if seat < 1 || seat > l.SeatsPerRow {
+			return ErrSpotLayoutInvalidSeat
+		}
+	}
+	for _, zone := range l.Zones {
+		first, err := RowIndex(zone.FirstRow)
+		if err != nil {
+			return ErrSpotLayoutInvalidZone
+		}
+		last, err := RowIndex(zone.LastRow)
+		if err != nil || zone.Name == "" || last < first {
+			return ErrSpotLayoutInvalidZone
+		}
+	}
+	return nil
+}
+
+// ZoneOf returns the name of the first zone containing row, or "" when the
+// row is not part of any zone.
+func (l *SpotLayout) ZoneOf(row string) string {
+	index, err := RowIndex(row)
+	if err != nil {
+		return ""
+	}
+	for _, zone := range l.Zones {
+		first, _ := RowIndex(zone.FirstRow)
+		last, _ := RowIndex(zone.LastRow)
+		if index >= first && index <= last {
+			return zone.Name
+		}
+	}
+	return ""
+}
+
+// SpotCount returns how many spots the layout creates, without creating
+// them.
+func (l *SpotLayout) SpotCount() (int, error) {
+	if err := l.Validate(); err != nil {
+		return 0, err
+	}
+	skipped := 0
+	for position := 1; position <= l.SeatsPerRow; position++ {
+		if slices.Contains(l.SkipSeats, position) {
+			skipped++
+		}
+	}
+	return l.Rows * (l.SeatsPerRow - skipped), nil
+}
+
+// SpotNames returns every spot name of the layout, row by row.
+func (l *SpotLayout) SpotNames() ([]string, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, l.Rows*l.SeatsPerRow)
+	index := 0
+	for range l.Rows {
+		label := RowLabel(index)
+		for slices.Contains(l.SkipRows, label) {
+			index++
+			label = RowLabel(index)
+		}
+		if len(label) > MaxRowLabelLength {
+			return nil, ErrSpotLayoutTooManyRows
+		}
+		index++
+
+		number := 0
+		for position := 1; position <= l.SeatsPerRow; position++ {
+			number++
+			if !slices.Contains(l.SkipSeats, position) {
+				names = append(names, label+strconv.Itoa(number))
+			}
+			if slices.Contains(l.Aisles, position) {
+				number++
+			}
+		}
+	}
+	return names, nil
+}
+
+// RowLabel converts a zero based row index into its label: 0 is "A",
+// 25 is "Z", 26 is "AA" and so on.
+func RowLabel(index int) string {
+	var label []byte
+	for index >= 0 {
+		label = append([]byte{byte('A' + index%26)}, label...)
+		index = index/26 - 1
+	}
+	return string(label)
+}
+
+// RowIndex is the inverse of RowLabel.
+func RowIndex(label string) (int, error) {
+	if label == "" || len(label) > MaxRowLabelLength {
+		return 0, ErrInvalidSpotRowLabel
+	}
+	index := 0
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if c < 'A' || c > 'Z' {
+			return 0, ErrInvalidSpotRowLabel
+		}
+		index = index*26 + int(c-'A') + 1
+	}
+	return index - 1, nil
+}
+
+// ParseSpotName splits a spot name into its row label and seat number.
+//
+// A valid name is one to MaxRowLabelLength uppercase letters followed by a
+// positive seat number without leading zeros, e.g. "A1", "AB12".
+func ParseSpotName(name string) (string, int, error) {
+	if name == "" {
+		return "", 0, ErrSpotNameRequired
+	}
+	if len(name) < 2 {
+		return "", 0, ErrSpotNameLessThanTwo
+	}
+	if name[0] < 'A' || name[0] > 'Z' {
+		return "", 0, ErrInvalidSpotNameFirstCharacter
+	}
+
+	split := strings.IndexFunc(name, func(r rune) bool { return r < 'A' || r > 'Z' })
+	if split == -1 {
+		return "", 0, ErrInvalidSpotNameLastCharacter
+	}
+	row, digits := name[:split], name[split:]
+	if len(row) > MaxRowLabelLength {
+		return "", 0, ErrInvalidSpotRowLabel
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return "", 0, ErrInvalidSpotNameLastCharacter
+		}
+	}
+	if digits[0] == '0' {
+		return "", 0, ErrInvalidSpotNumber
+	}
+	number, err := strconv.Atoi(digits)
+	if err != nil {
+		return "", 0, ErrInvalidSpotNumber
+	}
+	return row, number, nil
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseSpotName(t *testing.T) {
+	tests := []struct {
+		name   string
+		row    string
+		number int
+		err    error
+	}{
+		{name: "A1", row: "A", number: 1},
+		{name: "AB12", row: "AB", number: 12},
+		{name: "ZZZ100", row: "ZZZ", number: 100},
+		{name: "", err: ErrSpotNameRequired},
+		{name: "A", err: ErrSpotNameLessThanTwo},
+		{name: "1A", err: ErrInvalidSpotNameFirstCharacter},
+		{name: "a1", err: ErrInvalidSpotNameFirstCharacter},
+		{name: "AB", err: ErrInvalidSpotNameLastCharacter},
+		{name: "A1B", err: ErrInvalidSpotNameLastCharacter},
+		{name: "ABCD1", err: ErrInvalidSpotRowLabel},
+		{name: "A0", err: ErrInvalidSpotNumber},
+		{name: "A01", err: ErrInvalidSpotNumber},
+		{name: "A99999999999999999999", err: ErrInvalidSpotNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, number, err := ParseSpotName(tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseSpotName(%q) error = %v, want %v", tt.name, err, tt.err)
+			}
+			if row != tt.row || number != tt.number {
+				t.Errorf("ParseSpotName(%q) = %q, %d, want %q, %d", tt.name, row, number, tt.row, tt.number)
+			}
+		})
+	}
+}
+
+func TestRowLabel(t *testing.T) {
+	tests := []struct {
+		index int
+		label string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{18277, "ZZZ"},
+	}
+
+	for _, tt := range tests {
+		if label := RowLabel(tt.index); label != tt.label {
+			t.Errorf("RowLabel(%d) = %q, want %q", tt.index, label, tt.label)
+		}
+		if index, err := RowIndex(tt.label); err != nil || index != tt.index {
+			t.Errorf("RowIndex(%q) = %d, %v, want %d", tt.label, index, err, tt.index)
+		}
+	}
+}
+
+func TestRowIndexInvalid(t *testing.T) {
+	for _, label := range []string{"", "a", "A1", "AAAA"} {
+		if _, err := RowIndex(label); !errors.Is(err, ErrInvalidSpotRowLabel) {
+			t.Errorf("RowIndex(%q) error = %v, want %v", label, err, ErrInvalidSpotRowLabel)
+		}
+	}
+}
+
+func TestSpotLayoutSpotNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout SpotLayout
+		want   []string
+	}{
+		{
+			name:   "plain",
+			layout: SpotLayout{Rows: 2, SeatsPerRow: 2},
+			want:   []string{"A1", "A2", "B1", "B2"},
+		},
+		{
+			name:   "skipped rows",
+			layout: SpotLayout{Rows: 2, SeatsPerRow: 1, SkipRows: []string{"A", "C"}},
+			want:   []string{"B1", "D1"},
+		},
+		{
+			name:   "skipped seats keep their number",
+			layout: SpotLayout{Rows: 1, SeatsPerRow: 3, SkipSeats: []int{2}},
+			want:   []string{"A1", "A3"},
+		},
+		{
+			name:   "aisles skip a number",
+			layout: SpotLayout{Rows: 1, SeatsPerRow: 4, Aisles: []int{2}},
+			want:   []string{"A1", "A2", "A4", "A5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := tt.layout.SpotNames()
+			if err != nil {
+				t.Fatalf("SpotNames() error = %v", err)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("SpotNames() = %v, want %v", names, tt.want)
+			}
+			count, err := tt.layout.SpotCount()
+			if err != nil || count != len(tt.want) {
+				t.Errorf("SpotCount() = %d, %v, want %d", count, err, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSpotLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout SpotLayout
+		err    error
+	}{
+		{name: "valid", layout: SpotLayout{Rows: 10, SeatsPerRow: 20, Zones: []LayoutZone{{Name: "orchestra", FirstRow: "A", LastRow: "E"}}}},
+		{name: "no rows", layout: SpotLayout{Rows: 0, SeatsPerRow: 1}, err: ErrSpotLayoutInvalidRows},
+		{name: "no seats", layout: SpotLayout{Rows: 1, SeatsPerRow: 0}, err: ErrSpotLayoutInvalidSeatsPerRow},
+		{name: "too many spots", layout: SpotLayout{Rows: 1001, SeatsPerRow: 100}, err: ErrSpotLayoutTooManySpots},
+		{name: "invalid skip row", layout: SpotLayout{Rows: 1, SeatsPerRow: 1, SkipRows: []string{"a"}}, err: ErrSpotLayoutInvalidSkipRow},
+		{name: "skip seat out of range", layout: SpotLayout{Rows: 1, SeatsPerRow: 2, SkipSeats: []int{3}}, err: ErrSpotLayoutInvalidSeat},
+		{name: "aisle out of range", layout: SpotLayout{Rows: 1, SeatsPerRow: 2, Aisles: []int{0}}, err: ErrSpotLayoutInvalidSeat},
+		{name: "zone without name", layout: SpotLayout{Rows: 1, SeatsPerRow: 1, Zones: []LayoutZone{{FirstRow: "A", LastRow: "A"}}}, err: ErrSpotLayoutInvalidZone},
+		{name: "reversed zone", layout: SpotLayout{Rows: 2, SeatsPerRow: 1, Zones: []LayoutZone{{Name: "balcony", FirstRow: "B", LastRow: "A"}}}, err: ErrSpotLayoutInvalidZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSpotLayoutTooManyRows(t *testing.T) {
+	layout := SpotLayout{Rows: 18278, SeatsPerRow: 1, SkipRows: []string{"A"}}
+	if _, err := layout.SpotNames(); !errors.Is(err, ErrSpotLayoutTooManyRows) {
+		t.Errorf("SpotNames() error = %v, want %v", err, ErrSpotLayoutTooManyRows)
+	}
+}
+
+func TestSpotLayoutZoneOf(t *testing.T) {
+	layout := SpotLayout{
+		Rows:        6,
+		SeatsPerRow: 1,
+		Zones: []LayoutZone{
+			{Name: "orchestra", FirstRow: "A", LastRow: "C"},
+			{Name: "balcony", FirstRow: "D", LastRow: "E"},
+		},
+	}
+	tests := map[string]string{"A": "orchestra", "C": "orchestra", "D": "balcony", "F": "", "1": ""}
+	for row, want := range tests {
+		if zone := layout.ZoneOf(row); zone != want {
+			t.Errorf("ZoneOf(%q) = %q, want %q", row, zone, want)
+		}
+	}
+}
@@ -2,28 +2,53 @@ package domain
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TicketType string
 
+type TicketStatus string
+
+const (
+	TicketStatusActive    TicketStatus = "active"
+	TicketStatusCancelled TicketStatus = "cancelled"
+	TicketStatusUsed      TicketStatus = "used"
+)
+
 const (
 	TicketTypeHalf TicketType = "half"
 	TicketTypeFull TicketType = "full"
 )
 
 type Ticket struct {
-	Id         string
-	EventId    string
-	Spot       *Spot
-	TicketType TicketType
-	Price      float64
+	Id          string
+	EventId     string
+	OrderId     string
+	Spot        *Spot
+	TicketType  TicketType
+	Status      TicketStatus
+	Price       float64
+	Attendee    Attendee
+	HolderEmail string
+	CustomerId  string
+	SalesPhase  SalesPhaseKind
+	UsedAt      time.Time
+	GateId      string
+	// CredentialVersion grows every time the ticket changes hands, revoking
+	// the credentials issued to previous holders.
+	CredentialVersion int
+
+	domainEvents
 }
 
 var (
-	ErrTicketPriceLessThanZero = errors.New("ticker price must be greater than zero")
+	ErrTicketPriceLessThanZero = errors.New("ticket price must not be negative")
 	ErrInvalidTicketType       = errors.New("invalid ticket type")
+	ErrTicketNotFound          = errors.New("ticket not found")
+	ErrTicketAlreadyCancelled  = errors.New("ticket already cancelled")
+	ErrTicketAlreadyUsed       = errors.New("ticket already used")
 )
 
 func IsValidTicketType(ticketType TicketType) bool {
@@ -37,14 +62,16 @@ func (t *Ticket) CalculatePrice() {
 }
 
 func (t *Ticket) Validate() error {
-	if t.Price <= 0 {
+	if t.Price < 0 {
 		return ErrTicketPriceLessThanZero
 	}
 
 	return nil
 }
 
-func NewTicket(event *Event, spot *Spot, ticketType TicketType) (*Ticket, error) {
+// NewTicket issues a ticket of ticketType for spot, nil for general
+// admission, when a full ticket costs price.
+func NewTicket(event *Event, spot *Spot, ticketType TicketType, price float64) (*Ticket, error) {
 	if !IsValidTicketType(ticketType) {
 		return nil, ErrInvalidTicketType
 	}
@@ -54,7 +81,8 @@ func NewTicket(event *Event, spot *Spot, ticketType TicketType) (*Ticket, error)
 		EventId:    event.Id,
 		Spot:       spot,
 		TicketType: ticketType,
-		Price:      event.Price,
+		Status:     TicketStatusActive,
+		Price:      price,
 	}
 
 	ticket.CalculatePrice()
@@ -63,3 +91,31 @@ func NewTicket(event *Event, spot *Spot, ticketType TicketType) (*Ticket, error)
 	}
 	return ticket, nil
 }
+
+// Cancel voids the ticket and releases its spot, if any.
+func (t *Ticket) Cancel() error {
+	if t.Status == TicketStatusCancelled {
+		return ErrTicketAlreadyCancelled
+	}
+	if t.Status == TicketStatusUsed {
+		return ErrTicketAlreadyUsed
+	}
+
+	t.Status = TicketStatusCancelled
+	if t.Spot != nil {
+		t.Spot.Status = SpotStatusAvailable
+		t.Spot.TicketId = ""
+	}
+	t.record(DomainEventTicketCancelled, t.Id, t.EventId, map[string]any{
+		"order_id": t.OrderId,
+		"spot":     t.spotName(),
+	})
+	return nil
+}
+
+func (t *Ticket) spotName() string {
+	if t.Spot == nil {
+		return ""
+	}
+	return t.Spot.Name
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TicketCredential is the payload printed as a QR code on a ticket. It is
+// signed by a CredentialSigner so gates can trust it without looking the
+// ticket up.
+type TicketCredential struct {
+	KeyId      string
+	TicketId   string
+	EventId    string
+	Spot       string
+	Version    int
+	IssuedAt   time.Time
+	ValidUntil time.Time
+}
+
+// CredentialSigner turns credentials and check-in manifests into
+// tamper-proof strings and back. Sign uses the active key; Verify accepts
+// any key the signer still knows, so credentials issued before a key
+// rotation keep working. A credential never verifies as a manifest, nor a
+// manifest as a credential. PublicKeys lists those keys by id for gate
+// devices that verify offline.
+type CredentialSigner interface {
+	Sign(credential *TicketCredential) (string, error)
+	Verify(signed string) (*TicketCredential, error)
+	SignManifest(manifest *CheckInManifest) (string, error)
+	VerifyManifest(signed string) (*CheckInManifest, error)
+	PublicKeys() map[string][]byte
+}
+
+var (
+	ErrTicketCredentialInvalid      = errors.New("invalid ticket credential")
+	ErrTicketCredentialUnknownKey   = errors.New("ticket credential signed with an unknown key")
+	ErrTicketCredentialExpired      = errors.New("ticket credential expired")
+	ErrTicketCredentialNotYetValid  = errors.New("ticket credential is not valid yet")
+	ErrTicketCredentialRevoked      = errors.New("ticket credential was revoked by a transfer")
+	ErrTicketCredentialNotActive    = errors.New("credentials are only issued for active tickets")
+	ErrTicketCredentialInvalidGrace = errors.New("ticket credential grace period must not be negative")
+)
+
+// NewTicketCredential issues a credential for ticket valid from issuedAt
+// until grace after the event starts.
+func NewTicketCredential(ticket *Ticket, event *Event, issuedAt time.Time, grace time.Duration) (*TicketCredential, error) {
+	if ticket.Status != TicketStatusActive {
+		return nil, ErrTicketCredentialNotActive
+	}
+	if grace < 0 {
+		return nil, ErrTicketCredentialInvalidGrace
+	}
+
+	credential := &TicketCredential{
+		TicketId:   ticket.Id,
+		EventId:    event.Id,
+		Version:    ticket.CredentialVersion,
+		IssuedAt:   issuedAt.Truncate(time.Second),
+		ValidUntil: event.Date.Add(grace).Truncate(time.Second),
+	}
+	if ticket.Spot != nil {
+		credential.Spot = ticket.Spot.Name
+	}
+	return credential, nil
+}
+
+// ValidateFor checks that the credential was issued to the current holder
+// of ticket, not to someone who transferred it away since.
+func (c *TicketCredential) ValidateFor(ticket *Ticket) error {
+	return c.ValidateVersion(ticket.CredentialVersion)
+}
+
+// ValidateVersion checks that the credential is not older than version,
+// the credential version of the current holder.
+func (c *TicketCredential) ValidateVersion(version int) error {
+	if c.Version < version {
+		return ErrTicketCredentialRevoked
+	}
+	return nil
+}
+
+// ValidateAt checks the credential validity window.
+func (c *TicketCredential) ValidateAt(at time.Time) error {
+	if at.Before(c.IssuedAt) {
+		return ErrTicketCredentialNotYetValid
+	}
+	if !at.Before(c.ValidUntil) {
+		return ErrTicketCredentialExpired
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var credentialBase = time.Date(2030, time.May, 10, 20, 0, 0, 0, time.UTC)
+
+func credentialFor(ticketId string, version int) *TicketCredential {
+	return &TicketCredential{
+		TicketId:   ticketId,
+		EventId:    "event",
+		Version:    version,
+		IssuedAt:   credentialBase.Add(-24 * time.Hour),
+		ValidUntil: credentialBase.Add(2 * time.Hour),
+	}
+}
+
+func TestNewTicketCredential(t *testing.T) {
+	event := &Event{Id: "event", Date: credentialBase}
+	ticket := &Ticket{Id: "ticket", Status: TicketStatusActive, CredentialVersion: 3}
+
+	credential, err := NewTicketCredential(ticket, event, credentialBase.Add(-time.Hour+time.Millisecond), time.Hour)
+	if err != nil {
+		t.Fatalf("NewTicketCredential() error = %v", err)
+	}
+	if credential.Version != 3 || !credential.ValidUntil.Equal(credentialBase.Add(time.Hour)) || credential.IssuedAt.Nanosecond() != 0 {
+		t.Errorf("NewTicketCredential() = %+v", credential)
+	}
+
+	if _, err := NewTicketCredential(ticket, event, credentialBase, -time.Minute); !errors.Is(err, ErrTicketCredentialInvalidGrace) {
+		t.Errorf("NewTicketCredential() error = %v, want %v", err, ErrTicketCredentialInvalidGrace)
+	}
+	ticket.Status = TicketStatusCancelled
+	if _, err := NewTicketCredential(ticket, event, credentialBase, time.Hour); !errors.Is(err, ErrTicketCredentialNotActive) {
+		t.Errorf("NewTicketCredential() error = %v, want %v", err, ErrTicketCredentialNotActive)
+	}
+}
+
+func TestTicketCredentialValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		at      time.Time
+		err     error
+	}{
+		{name: "current holder", version: 2, at: credentialBase},
+		{name: "newer than the ticket", version: 1, at: credentialBase},
+		{name: "transferred away", version: 3, at: credentialBase, err: ErrTicketCredentialRevoked},
+		{name: "before issue", version: 2, at: credentialBase.Add(-25 * time.Hour), err: ErrTicketCredentialNotYetValid},
+		{name: "at expiry", version: 2, at: credentialBase.Add(2 * time.Hour), err: ErrTicketCredentialExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credential := credentialFor("ticket", 2)
+			err := credential.ValidateVersion(tt.version)
+			if err == nil {
+				err = credential.ValidateAt(tt.at)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("validation error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckInManifestAdmit(t *testing.T) {
+	manifest := &CheckInManifest{
+		EventId: "event",
+		Tickets: []CheckInManifestTicket{
+			{TicketId: "kept", CredentialVersion: 1},
+			{TicketId: "transferred", CredentialVersion: 2},
+		},
+		IssuedAt:   credentialBase.Add(-time.Hour),
+		ValidUntil: credentialBase.Add(time.Hour),
+	}
+	otherEvent := credentialFor("kept", 1)
+	otherEvent.EventId = "other"
+
+	tests := []struct {
+		name       string
+		credential *TicketCredential
+		at         time.Time
+		err        error
+	}{
+		{name: "admitted", credential: credentialFor("kept", 1), at: credentialBase},
+		{name: "new holder", credential: credentialFor("transferred", 2), at: credentialBase},
+		{name: "previous holder", credential: credentialFor("transferred", 1), at: credentialBase, err: ErrTicketCredentialRevoked},
+		{name: "not in manifest", credential: credentialFor("cancelled", 1), at: credentialBase, err: ErrCheckInNotInManifest},
+		{name: "other event", credential: otherEvent, at: credentialBase, err: ErrCheckInWrongEvent},
+		{name: "manifest expired", credential: credentialFor("kept", 1), at: credentialBase.Add(time.Hour), err: ErrCheckInManifestExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := manifest.Admit(tt.credential, tt.at); !errors.Is(err, tt.err) {
+				t.Errorf("Admit() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketTransferStatus string
+
+const (
+	TicketTransferStatusPending  TicketTransferStatus = "pending"
+	TicketTransferStatusAccepted TicketTransferStatus = "accepted"
+	TicketTransferStatusExpired  TicketTransferStatus = "expired"
+)
+
+// TicketTransfer hands a ticket from its current holder to someone else.
+// The recipient accepts it with a secret token; only the token hash is
+// stored.
+type TicketTransfer struct {
+	Id         string
+	TicketId   string
+	FromEmail  string
+	ToEmail    string
+	TokenHash  string
+	Status     TicketTransferStatus
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	AcceptedAt time.Time
+}
+
+// TicketHolder is an entry of a ticket's holder history.
+type TicketHolder struct {
+	TicketId   string
+	Email      string
+	TransferId string
+	Since      time.Time
+}
+
+var (
+	ErrTicketTransferNotFound      = errors.New("ticket transfer not found")
+	ErrTicketTransferNotHolder     = errors.New("only the current holder can transfer a ticket")
+	ErrTicketTransferSameHolder    = errors.New("ticket cannot be transferred to its current holder")
+	ErrTicketTransferPending       = errors.New("ticket already has a pending transfer")
+	ErrTicketTransferNotPending    = errors.New("ticket transfer is not pending")
+	ErrTicketTransferExpired       = errors.New("ticket transfer expired")
+	ErrTicketTransferInvalidToken  = errors.New("invalid ticket transfer token")
+	ErrTicketTransferNotActive     = errors.New("only active tickets can be transferred")
+	ErrTicketTransferInvalidExpiry = errors.New("ticket transfer duration must be greater than zero")
+)
+
+// NewTicketTransfer starts a transfer of ticket from fromEmail to toEmail
+// and returns it together with the plain token the recipient must present.
+func NewTicketTransfer(ticket *Ticket, fromEmail, toEmail string, duration time.Duration) (*TicketTransfer, string, error) {
+	fromEmail, toEmail = NormalizeEmail(fromEmail), NormalizeEmail(toEmail)
+	if ticket.Status != TicketStatusActive {
+		return nil, "", ErrTicketTransferNotActive
+	}
+	if fromEmail != ticket.HolderEmail {
+		return nil, "", ErrTicketTransferNotHolder
+	}
+	if toEmail == "" {
+		return nil, "", ErrOrderEmailRequired
+	}
+	if toEmail == fromEmail {
+		return nil, "", ErrTicketTransferSameHolder
+	}
+	if duration <= 0 {
+		return nil, "", ErrTicketTransferInvalidExpiry
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	return &TicketTransfer{
+		Id:        uuid.New().String(),
+		TicketId:  ticket.Id,
+		FromEmail: fromEmail,
+		ToEmail:   toEmail,
+		TokenHash: HashTransferToken(token),
+		Status:    TicketTransferStatusPending,
+		ExpiresAt: now.Add(duration),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashTransferToken returns the stored form of a transfer token.
+func HashTransferToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Accept completes the transfer, making the recipient the ticket holder.
+func (t *TicketTransfer) Accept(token string, ticket *Ticket, at time.Time) error {
+	if t.Status != TicketTransferStatusPending {
+		return ErrTicketTransferNotPending
+	}
+	if !at.Before(t.ExpiresAt) {
+		t.Status = TicketTransferStatusExpired
+		return ErrTicketTransferExpired
+	}
+	if subtle.ConstantTimeCompare([]byte(HashTransferToken(token)), []byte(t.TokenHash)) != 1 {
+		return ErrTicketTransferInvalidToken
+	}
+	if ticket.Status != TicketStatusActive {
+		return ErrTicketTransferNotActive
+	}
+	if ticket.HolderEmail != t.FromEmail {
+		return ErrTicketTransferNotHolder
+	}
+
+	t.Status = TicketTransferStatusAccepted
+	t.AcceptedAt = at
+	ticket.HolderEmail = t.ToEmail
+	ticket.CustomerId = ""
+	ticket.CredentialVersion++
+	ticket.record(DomainEventTicketTransferred, ticket.Id, ticket.EventId, map[string]any{
+		"transfer_id": t.Id,
+		"from_email":  t.FromEmail,
+		"to_email":    t.ToEmail,
+	})
+	return nil
+}
+
+// Expire closes a pending transfer whose deadline has passed.
+func (t *TicketTransfer) Expire(at time.Time) bool {
+	if t.Status != TicketTransferStatusPending || at.Before(t.ExpiresAt) {
+		return false
+	}
+	t.Status = TicketTransferStatusExpired
+	return true
+}
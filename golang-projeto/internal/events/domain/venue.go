@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVenueNotFound            = errors.New("venue not found")
+	ErrVenueNameRequired        = errors.New("venue name is required")
+	ErrVenueInvalidCoordinates  = errors.New("venue latitude must be between -90 and 90 and longitude between -180 and 180")
+	ErrVenueInvalidTimezone     = errors.New("venue timezone must be an IANA time zone, like America/Sao_Paulo")
+	ErrVenueInvalidCapacity     = errors.New("venue capacity must be greater than zero")
+	ErrVenueCapacityExceeded    = errors.New("event capacity exceeds the venue capacity")
+	ErrVenueLayoutNameRequired  = errors.New("venue layout name is required")
+	ErrVenueLayoutAlreadyExists = errors.New("venue already has a layout with this name")
+	ErrVenueLayoutNotFound      = errors.New("venue layout not found")
+)
+
+type Address struct {
+	Street     string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+// String joins the filled parts of the address.
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.Street, a.City, a.State, a.PostalCode, a.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Venue is a place where events of an organization happen. Its named
+// layouts are seating plans reused by every seated event held there.
+// Event dates are given in the venue Timezone.
+type Venue struct {
+	Id             string
+	OrganizationId string
+	Name           string
+	Address        Address
+	Latitude       float64
+	Longitude      float64
+	Timezone       string
+	Capacity       int
+	Layouts        []VenueLayout
+	CreatedAt      time.Time
+}
+
+// VenueLayout is a seating plan of a venue, stored under a name such as
+// "theater" or "arena".
+type VenueLayout struct {
+	Name   string
+	Layout SpotLayout
+}
+
+func NewVenue(organization *Organization, name string, address Address, latitude, longitude float64, timezone string, capacity int) (*Venue, error) {
+	venue := &Venue{
+		Id:             uuid.New().String(),
+		OrganizationId: organization.Id,
+		Name:           strings.TrimSpace(name),
+		Address:        address,
+		Latitude:       latitude,
+		Longitude:      longitude,
+		Timezone:       timezone,
+		Capacity:       capacity,
+		CreatedAt:      time.Now(),
+	}
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (v *Venue) Validate() error {
+	if v.Name == "" {
+		return ErrVenueNameRequired
+	}
+	if v.Latitude < -90 || v.Latitude > 90 || v.Longitude < -180 || v.Longitude > 180 {
+		return ErrVenueInvalidCoordinates
+	}
+	if _, err := v.Location(); err != nil {
+		return err
+	}
+	if v.Capacity <= 0 {
+		return ErrVenueInvalidCapacity
+	}
+	return nil
+}
+
+// Location returns the time zone of the venue.
+func (v *Venue) Location() (*time.Location, error) {
+	if v.Timezone == "" || strings.EqualFold(v.Timezone, "local") {
+		return nil, ErrVenueInvalidTimezone
+	}
+	location, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return nil, ErrVenueInvalidTimezone
+	}
+	return location, nil
+}
+
+// AddLayout stores a named seating plan. The layout must be valid and fit
+// in the venue capacity.
+func (v *Venue) AddLayout(name string, layout SpotLayout) (*VenueLayout, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrVenueLayoutNameRequired
+	}
+	if slices.ContainsFunc(v.Layouts, func(existing VenueLayout) bool { return existing.Name == name }) {
+		return nil, ErrVenueLayoutAlreadyExists
+	}
+
+	count, err := layout.SpotCount()
+	if err != nil {
+		return nil, err
+	}
+	if count > v.Capacity {
+		return nil, ErrVenueCapacityExceeded
+	}
+	names, err := layout.SpotNames()
+	if err != nil {
+		return nil, err
+	}
+	for spot := range layout.Attributes {
+		if !slices.Contains(names, spot) {
+			return nil, ErrSpotLayoutUnknownSpot
+		}
+	}
+
+	v.Layouts = append(v.Layouts, VenueLayout{Name: name, Layout: layout})
+	return &v.Layouts[len(v.Layouts)-1], nil
+}
+
+// Layout returns the layout stored under name.
+func (v *Venue) Layout(name string) (*VenueLayout, error) {
+	for i := range v.Layouts {
+		if v.Layouts[i].Name == name {
+			return &v.Layouts[i], nil
+		}
+	}
+	return nil, ErrVenueLayoutNotFound
+}
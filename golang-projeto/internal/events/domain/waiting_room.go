@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWaitingRoomNotFound          = errors.New("waiting room not found")
+	ErrWaitingRoomNotActive         = errors.New("waiting room is not active")
+	ErrWaitingRoomInvalidRate       = errors.New("admission rate must be greater than zero")
+	ErrWaitingRoomInvalidTTL        = errors.New("admission time to live must be greater than zero")
+	ErrWaitingRoomSession           = errors.New("sessions share the waiting room of their recurring event")
+	ErrWaitingRoomEntryNotFound     = errors.New("waiting room entry not found")
+	ErrAdmissionTokenRequired       = errors.New("an admission token is required while the waiting room is active")
+	ErrAdmissionTokenInvalid        = errors.New("invalid admission token")
+	ErrAdmissionTokenNotYetAdmitted = errors.New("admission token was not admitted yet")
+	ErrAdmissionTokenExpired        = errors.New("admission token expired")
+	ErrAdmissionTokenUsed           = errors.New("admission token was already used")
+	ErrAdmissionTokenOtherCustomer  = errors.New("admission token belongs to another customer")
+)
+
+// WaitingRoom queues the customers of a high-demand event while it is
+// active, letting AdmissionRate of them per minute through to checkout.
+// An admitted customer has AdmissionTTL to complete their purchase.
+type WaitingRoom struct {
+	EventId         string
+	Active          bool
+	AdmissionRate   int
+	AdmissionTTL    time.Duration
+	LastAdmissionAt time.Time
+}
+
+func NewWaitingRoom(event *Event, active bool, admissionRate int, admissionTTL time.Duration) (*WaitingRoom, error) {
+	if event.IsSession() {
+		return nil, ErrWaitingRoomSession
+	}
+	if admissionRate <= 0 {
+		return nil, ErrWaitingRoomInvalidRate
+	}
+	if admissionTTL <= 0 {
+		return nil, ErrWaitingRoomInvalidTTL
+	}
+	return &WaitingRoom{
+		EventId:         event.Id,
+		Active:          active,
+		AdmissionRate:   admissionRate,
+		AdmissionTTL:    admissionTTL,
+		LastAdmissionAt: time.Now(),
+	}, nil
+}
+
+func (r *WaitingRoom) admissionInterval() time.Duration {
+	return time.Minute / time.Duration(r.AdmissionRate)
+}
+
+// AdmissionsDue returns how many customers may be admitted at the given
+// time to keep up with the admission rate.
+func (r *WaitingRoom) AdmissionsDue(at time.Time) int {
+	if !r.Active || at.Before(r.LastAdmissionAt) {
+		return 0
+	}
+	return int(at.Sub(r.LastAdmissionAt) / r.admissionInterval())
+}
+
+// RecordAdmissions moves the admission clock forward after admitting
+// customers. When fewer than due were waiting, the clock restarts at the
+// given time, so an empty queue does not build up admissions for later.
+func (r *WaitingRoom) RecordAdmissions(admitted, due int, at time.Time) {
+	if admitted < due {
+		r.LastAdmissionAt = at
+		return
+	}
+	r.LastAdmissionAt = r.LastAdmissionAt.Add(time.Duration(admitted) * r.admissionInterval())
+}
+
+// EstimatedWait is the expected wait of the customer at the given position
+// of the queue, starting at 1.
+func (r *WaitingRoom) EstimatedWait(position int) time.Duration {
+	return time.Duration(position) * r.admissionInterval()
+}
+
+type WaitingRoomEntryStatus string
+
+const (
+	WaitingRoomEntryWaiting  WaitingRoomEntryStatus = "waiting"
+	WaitingRoomEntryAdmitted WaitingRoomEntryStatus = "admitted"
+	WaitingRoomEntryConsumed WaitingRoomEntryStatus = "consumed"
+)
+
+// WaitingRoomEntry is a customer in the queue. Its Token is the admission
+// token presented at checkout once admitted. Seq gives the FIFO order and
+// is set by the repository. The first customer to check out with the token
+// becomes its UserId, and a successful checkout consumes it.
+type WaitingRoomEntry struct {
+	Token      string
+	EventId    string
+	Seq        int64
+	UserId     string
+	Status     WaitingRoomEntryStatus
+	JoinedAt   time.Time
+	AdmittedAt time.Time
+	ExpiresAt  time.Time
+}
+
+func NewWaitingRoomEntry(room *WaitingRoom) (*WaitingRoomEntry, error) {
+	if !room.Active {
+		return nil, ErrWaitingRoomNotActive
+	}
+	return &WaitingRoomEntry{
+		Token:    uuid.New().String(),
+		EventId:  room.EventId,
+		Status:   WaitingRoomEntryWaiting,
+		JoinedAt: time.Now(),
+	}, nil
+}
+
+// ValidateAdmission checks that the entry lets principal check out of the
+// given event at the given time.
+func (e *WaitingRoomEntry) ValidateAdmission(eventId string, principal *Principal, at time.Time) error {
+	if e.EventId != eventId {
+		return ErrAdmissionTokenInvalid
+	}
+	if e.Status == WaitingRoomEntryConsumed {
+		return ErrAdmissionTokenUsed
+	}
+	if e.Status != WaitingRoomEntryAdmitted {
+		return ErrAdmissionTokenNotYetAdmitted
+	}
+	if !at.Before(e.ExpiresAt) {
+		return ErrAdmissionTokenExpired
+	}
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+	if e.UserId != "" && e.UserId != principal.UserId {
+		return ErrAdmissionTokenOtherCustomer
+	}
+	return nil
+}
+
+// Consume binds the entry to principal and uses it up for a checkout.
+func (e *WaitingRoomEntry) Consume(principal *Principal) {
+	e.UserId = principal.UserId
+	e.Status = WaitingRoomEntryConsumed
+}
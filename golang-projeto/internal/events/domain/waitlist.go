@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusFulfilled WaitlistStatus = "fulfilled"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+)
+
+// WaitlistEntry is a customer waiting for tickets of a sold-out event.
+// Entries are served in the order they were created; when spots are
+// released the first waiting entry receives them as a time-limited hold
+// of its ticket type.
+type WaitlistEntry struct {
+	Id             string
+	EventId        string
+	Email          string
+	Quantity       int
+	TicketType     TicketType
+	Status         WaitlistStatus
+	HoldId         string
+	OfferExpiresAt time.Time
+	CreatedAt      time.Time
+}
+
+var (
+	ErrWaitlistEntryNotFound     = errors.New("waitlist entry not found")
+	ErrWaitlistInvalidQuantity   = errors.New("waitlist quantity must be greater than zero")
+	ErrWaitlistTicketsAvailable  = errors.New("event still has tickets available")
+	ErrWaitlistEntryNotWaiting   = errors.New("waitlist entry is not waiting")
+	ErrWaitlistEntryNotOffered   = errors.New("waitlist entry has no pending offer")
+	ErrWaitlistAlreadyRegistered = errors.New("email is already on the waitlist for this event")
+)
+
+// NewWaitlistEntry registers email for quantity tickets of ticketType,
+// full price when empty.
+func NewWaitlistEntry(event *Event, email string, quantity int, ticketType TicketType) (*WaitlistEntry, error) {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return nil, ErrOrderEmailRequired
+	}
+	if quantity <= 0 {
+		return nil, ErrWaitlistInvalidQuantity
+	}
+	if ticketType == "" {
+		ticketType = TicketTypeFull
+	}
+	if !IsValidTicketType(ticketType) {
+		return nil, ErrInvalidTicketType
+	}
+
+	return &WaitlistEntry{
+		Id:         uuid.New().String(),
+		EventId:    event.Id,
+		Email:      email,
+		Quantity:   quantity,
+		TicketType: ticketType,
+		Status:     WaitlistStatusWaiting,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// Offer gives the entry the tickets kept by holdId, its spots or general
+// admission capacity, until the hold expires.
+func (w *WaitlistEntry) Offer(holdId string, expiresAt time.Time) error {
+	if w.Status != WaitlistStatusWaiting {
+		return ErrWaitlistEntryNotWaiting
+	}
+
+	w.Status = WaitlistStatusOffered
+	w.HoldId = holdId
+	w.OfferExpiresAt = expiresAt
+	return nil
+}
+
+// Close ends an offer after it expired: it is fulfilled when the customer
+// placed a paid order with the hold and expired otherwise.
+func (w *WaitlistEntry) Close(bought bool) error {
+	if w.Status != WaitlistStatusOffered {
+		return ErrWaitlistEntryNotOffered
+	}
+
+	if bought {
+		w.Status = WaitlistStatusFulfilled
+	} else {
+		w.Status = WaitlistStatusExpired
+	}
+	return nil
+}
+
+// WaitlistNotifier tells customers that spots are being held for them.
+type WaitlistNotifier interface {
+	NotifyWaitlistOffer(entry *WaitlistEntry, event *Event, hold *Hold) error
+}
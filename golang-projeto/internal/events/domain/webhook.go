@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AllWebhookEventTypes subscribes a webhook to every domain event.
+const AllWebhookEventTypes = "*"
+
+const webhookMinSecretLength = 16
+
+var (
+	ErrWebhookInvalidURL            = errors.New("webhook url must be an absolute https url to a public host")
+	ErrWebhookSecretTooShort        = errors.New("webhook secret must have at least 16 characters")
+	ErrWebhookEventTypesRequired    = errors.New("webhook must subscribe to at least one event type")
+	ErrWebhookInvalidEventType      = errors.New("invalid webhook event type")
+	ErrWebhookSubscriptionNotFound  = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotDeadLetter = errors.New("only dead-lettered webhook deliveries can be retried")
+	ErrWebhookInvalidDeliveryStatus = errors.New("invalid webhook delivery status")
+)
+
+// WebhookSubscription is an integrator endpoint receiving the domain events
+// of the given types raised by the events of its organization. Secret signs
+// every delivery, so the integrator can tell they come from us.
+type WebhookSubscription struct {
+	Id             string
+	OrganizationId string
+	URL            string
+	Secret         string
+	EventTypes     []string
+	Active         bool
+	CreatedAt      time.Time
+}
+
+// NewWebhookSubscription validates a subscription. An empty secret is
+// replaced by a random one, which the caller must hand to the integrator.
+// Hosts given as internal addresses are refused here; names resolving to
+// them are refused by the sender when delivering.
+func NewWebhookSubscription(organizationId, rawURL, secret string, eventTypes []string) (*WebhookSubscription, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.Hostname() == "localhost" {
+		return nil, ErrWebhookInvalidURL
+	}
+	if ip, err := netip.ParseAddr(parsed.Hostname()); err == nil && !IsPublicAddress(ip) {
+		return nil, ErrWebhookInvalidURL
+	}
+
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+	} else if len(secret) < webhookMinSecretLength {
+		return nil, ErrWebhookSecretTooShort
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, ErrWebhookEventTypesRequired
+	}
+	var types []string
+	for _, eventType := range eventTypes {
+		if eventType != AllWebhookEventTypes && !IsValidDomainEventName(eventType) {
+			return nil, ErrWebhookInvalidEventType
+		}
+		if !slices.Contains(types, eventType) {
+			types = append(types, eventType)
+		}
+	}
+
+	return &WebhookSubscription{
+		Id:             uuid.New().String(),
+		OrganizationId: organizationId,
+		URL:            rawURL,
+		Secret:         secret,
+		EventTypes:     types,
+		Active:         true,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, not
+// covered by netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddress tells whether webhooks may be delivered to ip: loopback,
+// link-local, private and other internal addresses are refused so
+// integrators cannot reach our own network through their endpoints.
+func IsPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// Accepts reports whether the subscription wants events named name.
+func (s *WebhookSubscription) Accepts(name string) bool {
+	return s.Active && (slices.Contains(s.EventTypes, AllWebhookEventTypes) || slices.Contains(s.EventTypes, name))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+func IsValidWebhookDeliveryStatus(status WebhookDeliveryStatus) bool {
+	switch status {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is one domain event to be sent to one subscription. Its
+// body is fixed when enqueued, so retries send the same content. A delivery
+// that keeps failing ends up dead-lettered, where it stays until retried by
+// hand.
+type WebhookDelivery struct {
+	Id             string
+	SubscriptionId string
+	DomainEventId  string
+	EventName      string
+	Body           []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    time.Time
+}
+
+func NewWebhookDelivery(subscription *WebhookSubscription, event DomainEvent) (*WebhookDelivery, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &WebhookDelivery{
+		Id:             uuid.New().String(),
+		SubscriptionId: subscription.Id,
+		DomainEventId:  event.Id,
+		EventName:      event.Name,
+		Body:           body,
+		Status:         WebhookDeliveryPending,
+		CreatedAt:      now,
+		NextAttemptAt:  now,
+	}, nil
+}
+
+func (d *WebhookDelivery) MarkDelivered(statusCode int, at time.Time) {
+	d.Status = WebhookDeliveryDelivered
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = at
+}
+
+// MarkFailed records a failed attempt. statusCode is zero when no response
+// was received. The next attempt waits retryDelay, doubled after every
+// failure; after maxAttempts the delivery is dead-lettered.
+func (d *WebhookDelivery) MarkFailed(statusCode int, err error, at time.Time, maxAttempts int, retryDelay time.Duration) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = err.Error()
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryDead
+		return
+	}
+	d.NextAttemptAt = at.Add(retryDelay << (d.Attempts - 1))
+}
+
+// Retry puts a dead-lettered delivery back in the queue with a fresh set of
+// attempts.
+func (d *WebhookDelivery) Retry(at time.Time) error {
+	if d.Status != WebhookDeliveryDead {
+		return ErrWebhookDeliveryNotDeadLetter
+	}
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	return nil
+}
+
+// WebhookSender posts a signed delivery and returns the response status.
+type WebhookSender interface {
+	Send(url string, headers map[string]string, body []byte) (statusCode int, err error)
+}
+
+// SignWebhook signs a delivery body sent at the given time. The signature
+// is sent as "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>">";
+// integrators recompute it with their secret and should reject old
+// timestamps to prevent replays.
+func SignWebhook(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestSignWebhook(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	signature := SignWebhook("whsec_0123456789abcdef", at, []byte(`{"id":"1"}`))
+	want := "t=1700000000,v1=a9dde36b92a3804d07aae8c5c12974ebd0f1a5107fb4823bca502467943477ca"
+	if signature != want {
+		t.Errorf("SignWebhook() = %q, want %q", signature, want)
+	}
+	if other := SignWebhook("whsec_0123456789abcdef", at.Add(time.Second), []byte(`{"id":"1"}`)); other == signature {
+		t.Errorf("SignWebhook() ignores the timestamp")
+	}
+}
+
+func TestWebhookDeliveryMarkFailed(t *testing.T) {
+	const maxAttempts = 4
+	const retryDelay = time.Minute
+	at := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		attempts int
+		status   WebhookDeliveryStatus
+		delay    time.Duration
+	}{
+		{attempts: 1, status: WebhookDeliveryPending, delay: time.Minute},
+		{attempts: 2, status: WebhookDeliveryPending, delay: 2 * time.Minute},
+		{attempts: 3, status: WebhookDeliveryPending, delay: 4 * time.Minute},
+		{attempts: 4, status: WebhookDeliveryDead},
+	}
+
+	delivery := &WebhookDelivery{Status: WebhookDeliveryPending}
+	for _, tt := range tests {
+		delivery.MarkFailed(500, errors.New("internal server error"), at, maxAttempts, retryDelay)
+		if delivery.Attempts != tt.attempts || delivery.Status != tt.status {
+			t.Fatalf("MarkFailed() attempts = %d, status = %s, want %d, %s", delivery.Attempts, delivery.Status, tt.attempts, tt.status)
+		}
+		if tt.status == WebhookDeliveryPending && !delivery.NextAttemptAt.Equal(at.Add(tt.delay)) {
+			t.Errorf("MarkFailed() after %d attempts next attempt = %s, want %s", tt.attempts, delivery.NextAttemptAt, at.Add(tt.delay))
+		}
+	}
+	if delivery.LastStatusCode != 500 || delivery.LastError != "internal server error" {
+		t.Errorf("MarkFailed() last status = %d, error = %q", delivery.LastStatusCode, delivery.LastError)
+	}
+
+	if err := delivery.Retry(at); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+	if delivery.Status != WebhookDeliveryPending || delivery.Attempts != 0 {
+		t.Errorf("Retry() status = %s, attempts = %d", delivery.Status, delivery.Attempts)
+	}
+	if err := delivery.Retry(at); !errors.Is(err, ErrWebhookDeliveryNotDeadLetter) {
+		t.Errorf("Retry() error = %v, want %v", err, ErrWebhookDeliveryNotDeadLetter)
+	}
+}
+
+func TestNewWebhookSubscription(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	tests := []struct {
+		name       string
+		url        string
+		secret     string
+		eventTypes []string
+		err        error
+	}{
+		{name: "valid", url: "https://hooks.example.com/events", secret: secret, eventTypes: []string{DomainEventTicketCreated}},
+		{name: "generated secret", url: "https://hooks.example.com/events", eventTypes: []string{AllWebhookEventTypes}},
+		{name: "http", url: "http://hooks.example.com/events", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "relative", url: "/events", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "localhost", url: "https://localhost/events", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "loopback", url: "https://127.0.0.1/events", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "metadata service", url: "https://169.254.169.254/latest", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "private ipv6", url: "https://[fd00::1]/events", secret: secret, eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookInvalidURL},
+		{name: "short secret", url: "https://hooks.example.com/events", secret: "short", eventTypes: []string{AllWebhookEventTypes}, err: ErrWebhookSecretTooShort},
+		{name: "no event types", url: "https://hooks.example.com/events", secret: secret, err: ErrWebhookEventTypesRequired},
+		{name: "unknown event type", url: "https://hooks.example.com/events", secret: secret, eventTypes: []string{"ticket.lost"}, err: ErrWebhookInvalidEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewWebhookSubscription("organization", tt.url, tt.secret, tt.eventTypes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewWebhookSubscription() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && len(subscription.Secret) < webhookMinSecretLength {
+				t.Errorf("NewWebhookSubscription() secret = %q", subscription.Secret)
+			}
+		})
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::":    true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"100.64.0.1":           false,
+		"169.254.169.254":      false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fe80::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:93.184.216.34": true,
+	}
+	for address, want := range tests {
+		if got := IsPublicAddress(netip.MustParseAddr(address)); got != want {
+			t.Errorf("IsPublicAddress(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const clockLeeway = 30 * time.Second
+
+// JWTVerifier validates access tokens signed with HS256, using a shared
+// secret, or RS256, using the public keys of a JWKS. Tokens must expire and
+// carry the user id in "sub"; "email" and "roles" are optional claims.
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+type accessClaims struct {
+	jwt.RegisteredClaims
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+// NewJWTVerifier accepts HS256 tokens when hmacSecret is set and RS256
+// tokens signed by rsaKeys, indexed by key id. issuer and audience are
+// only checked when not empty.
+func NewJWTVerifier(hmacSecret []byte, rsaKeys map[string]*rsa.PublicKey, issuer, audience string) (*JWTVerifier, error) {
+	var methods []string
+	if len(hmacSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no JWT verification key configured")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockLeeway),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &JWTVerifier{
+		hmacSecret: hmacSecret,
+		rsaKeys:    rsaKeys,
+		parser:     jwt.NewParser(options...),
+	}, nil
+}
+
+func (v *JWTVerifier) Verify(raw string) (*domain.Principal, error) {
+	var claims accessClaims
+	if _, err := v.parser.ParseWithClaims(raw, &claims, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domain.ErrInvalidToken)
+	}
+
+	principal := &domain.Principal{UserId: claims.Subject, Email: claims.Email}
+	for _, role := range claims.Roles {
+		principal.Roles = append(principal.Roles, domain.Role(role))
+	}
+	return principal, nil
+}
+
+func (v *JWTVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return v.hmacSecret, nil
+	case *jwt.SigningMethodRSA:
+		// Um JWKS com uma única chave dispensa o "kid" no token.
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		key, ok := v.rsaKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// LoadJWKSFile reads the RSA signing keys of a JSON Web Key Set file,
+// indexed by key id. Keys of other types or uses are skipped.
+func LoadJWKSFile(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS %s: %w", path, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: invalid modulus: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: invalid exponent: %w", key.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 {
+			return nil, fmt.Errorf("JWKS key %q: invalid exponent", key.Kid)
+		}
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS %s has no RSA signing keys", path)
+	}
+	return keys, nil
+}
@@ -0,0 +1,197 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	pageMargin   = 15.0
+	qrCodeSize   = 60.0
+	qrCodePixels = 512
+	displayDate  = "02/01/2006 15:04 MST"
+)
+
+// PDFRenderer renders an order as an A4 PDF with one page per ticket,
+// carrying its QR code, followed by a receipt page. It only uses the core
+// PDF fonts, so no font files are needed at runtime.
+type PDFRenderer struct{}
+
+func NewPDFRenderer() domain.OrderDocumentRenderer {
+	return &PDFRenderer{}
+}
+
+func (r *PDFRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+func (r *PDFRenderer) RenderOrder(document *domain.OrderDocument) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(document.Event.Name, true)
+	pdf.SetCreator("imersao18 events", true)
+
+	// As fontes padrão do PDF usam cp1252; o texto em UTF-8 é convertido.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i := range document.Order.Tickets {
+		ticket := &document.Order.Tickets[i]
+		if err := r.renderTicket(pdf, tr, document, ticket); err != nil {
+			return nil, err
+		}
+	}
+	r.renderReceipt(pdf, tr, document)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *PDFRenderer) renderTicket(pdf *fpdf.Fpdf, tr func(string) string, document *domain.OrderDocument, ticket *domain.Ticket) error {
+	event := document.Event
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.MultiCell(0, 9, tr(event.Name), "", "L", false)
+	pdf.Ln(2)
+
+	top := pdf.GetY()
+	textWidth := 210 - 2*pageMargin - qrCodeSize - 5
+
+	pdf.SetFont("Helvetica", "", 11)
+	field := func(label, value string) {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(textWidth, 5, tr(strings.ToUpper(label)), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(textWidth, 6, tr(value), "", "L", false)
+		pdf.Ln(1.5)
+	}
+
+	field("Date", event.LocalDate().Format(displayDate))
+	field("Location", event.Location)
+	if event.Organization != "" {
+		field("Organization", event.Organization)
+	}
+	field("Spot", spotLabel(ticket))
+	field("Ticket type", ticketTypeLabel(ticket.TicketType))
+	field("Price", formatPrice(ticket.Price))
+	if ticket.Attendee.Name != "" {
+		field("Attendee", ticket.Attendee.Name)
+	}
+	field("Ticket", ticket.Id)
+	bottom := pdf.GetY()
+
+	x := 210 - pageMargin - qrCodeSize
+	credential, ok := document.Credentials[ticket.Id]
+	if !ok {
+		pdf.SetXY(x, top+qrCodeSize/2-5)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.SetTextColor(200, 30, 30)
+		pdf.CellFormat(qrCodeSize, 10, strings.ToUpper(string(ticket.Status)), "1", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	} else {
+		png, err := qrcode.Encode(credential, qrcode.Medium, qrCodePixels)
+		if err != nil {
+			return err
+		}
+		options := fpdf.ImageOptions{ImageType: "PNG"}
+		name := "qr-" + ticket.Id
+		pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(png))
+		pdf.ImageOptions(name, x, top, qrCodeSize, qrCodeSize, false, options, 0, "")
+	}
+
+	pdf.SetY(max(bottom, top+qrCodeSize) + 6)
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.Line(pageMargin, pdf.GetY(), 210-pageMargin, pdf.GetY())
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.MultiCell(0, 4.5, tr("Present this QR code at the entrance. Each ticket admits one person once; "+
+		"copies are rejected after the first scan."), "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+
+	return pdf.Error()
+}
+
+func (r *PDFRenderer) renderReceipt(pdf *fpdf.Fpdf, tr func(string) string, document *domain.OrderDocument) {
+	order := document.Order
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 10, "Receipt", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 11)
+	for _, line := range [][2]string{
+		{"Order", order.Id},
+		{"Date", order.CreatedAt.In(document.Event.TimeLocation()).Format(displayDate)},
+		{"Email", order.Email},
+		{"Event", document.Event.Name},
+	} {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(25, 6, tr(line[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, 6, tr(line[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	widths := []float64{72, 35, 25, 25, 23}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	for i, header := range []string{"Ticket", "Spot", "Type", "Status", "Price"} {
+		align := "L"
+		if i == len(widths)-1 {
+			align = "R"
+		}
+		pdf.CellFormat(widths[i], 7, header, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, ticket := range order.Tickets {
+		pdf.CellFormat(widths[0], 6, ticket.Id, "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, tr(spotLabel(&ticket)), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, ticketTypeLabel(ticket.TicketType), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 6, string(ticket.Status), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[4], 6, formatPrice(ticket.Price), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2]+widths[3], 8, "Total", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(widths[4], 8, formatPrice(order.Total), "T", 1, "R", false, 0, "")
+}
+
+func spotLabel(ticket *domain.Ticket) string {
+	if ticket.Spot == nil {
+		return "General admission"
+	}
+	if ticket.Spot.Zone != "" {
+		return ticket.Spot.Name + " (" + ticket.Spot.Zone + ")"
+	}
+	return ticket.Spot.Name
+}
+
+func ticketTypeLabel(ticketType domain.TicketType) string {
+	switch ticketType {
+	case domain.TicketTypeHalf:
+		return "Half"
+	case domain.TicketTypeFull:
+		return "Full"
+	default:
+		return string(ticketType)
+	}
+}
+
+func formatPrice(price float64) string {
+	return fmt.Sprintf("R$ %.2f", price)
+}
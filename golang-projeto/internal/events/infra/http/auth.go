@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type principalKey struct{}
+
+// Authenticate reads the bearer token of every request and stores whom it
+// was issued to in the request context. Requests without a token go on
+// anonymously; requests with an invalid one are rejected.
+func Authenticate(verifier domain.TokenVerifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			writeError(w, domain.ErrInvalidToken)
+			return
+		}
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// PrincipalFromContext returns the authenticated user of a request, or nil
+// for anonymous requests.
+func PrincipalFromContext(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal
+}
+
+// RequireAuth rejects anonymous requests.
+func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if PrincipalFromContext(r.Context()) == nil {
+			writeError(w, domain.ErrUnauthenticated)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// RequireRole only lets through users with any of the given roles.
+func RequireRole(next http.HandlerFunc, roles ...domain.Role) http.HandlerFunc {
+	return RequireAuth(func(w http.ResponseWriter, r *http.Request) {
+		if !PrincipalFromContext(r.Context()).HasRole(roles...) {
+			writeError(w, domain.ErrForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
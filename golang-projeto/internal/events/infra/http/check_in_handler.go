@@ -0,0 +1,70 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type CheckInHandler struct {
+	checkInTicketUseCase      *usecase.CheckInTicketUseCase
+	getCheckInManifestUseCase *usecase.GetCheckInManifestUseCase
+	listCredentialKeysUseCase *usecase.ListCredentialKeysUseCase
+}
+
+func NewCheckInHandler(
+	checkInTicketUseCase *usecase.CheckInTicketUseCase,
+	getCheckInManifestUseCase *usecase.GetCheckInManifestUseCase,
+	listCredentialKeysUseCase *usecase.ListCredentialKeysUseCase,
+) *CheckInHandler {
+	return &CheckInHandler{
+		checkInTicketUseCase:      checkInTicketUseCase,
+		getCheckInManifestUseCase: getCheckInManifestUseCase,
+		listCredentialKeysUseCase: listCredentialKeysUseCase,
+	}
+}
+
+func (h *CheckInHandler) CheckInTicket(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CheckInTicketInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.checkInTicketUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *CheckInHandler) GetCheckInManifest(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetCheckInManifestInputDTO{OrganizationId: organizationId(r), EventId: r.PathValue("eventId")}
+	output, err := h.getCheckInManifestUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *CheckInHandler) ListCredentialKeys(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listCredentialKeysUseCase.Execute()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
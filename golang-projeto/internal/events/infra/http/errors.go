@@ -0,0 +1,209 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+var (
+	unauthorizedErrors = []error{
+		domain.ErrUnauthenticated,
+		domain.ErrInvalidToken,
+	}
+	notFoundErrors = []error{
+		domain.ErrEventNotFound,
+		domain.ErrSpotNotFound,
+		domain.ErrHoldNotFound,
+		domain.ErrOrderNotFound,
+		domain.ErrTicketNotFound,
+		domain.ErrWaitlistEntryNotFound,
+		domain.ErrTicketTransferNotFound,
+		domain.ErrWebhookSubscriptionNotFound,
+		domain.ErrWebhookDeliveryNotFound,
+		domain.ErrWaitingRoomNotFound,
+		domain.ErrWaitingRoomEntryNotFound,
+		domain.ErrCustomerNotFound,
+		domain.ErrOrganizationNotFound,
+		domain.ErrVenueNotFound,
+		domain.ErrVenueLayoutNotFound,
+	}
+	conflictErrors = []error{
+		domain.ErrSpotAlreadyReserved,
+		domain.ErrSpotAlreadyExists,
+		domain.ErrSpotHeld,
+		domain.ErrHoldExpired,
+		domain.ErrSeatSelectionNotAvailable,
+		domain.ErrSpotRestricted,
+		domain.ErrEventSoldOut,
+		domain.ErrEventCapacityExceeded,
+		domain.ErrEventCancelled,
+		domain.ErrEventSalesNotStarted,
+		domain.ErrEventSalesEnded,
+		domain.ErrSalesPhaseNotOpen,
+		domain.ErrSalesPhaseSoldOut,
+		domain.ErrVenueLayoutAlreadyExists,
+		domain.ErrPurchaseLimitExceeded,
+		domain.ErrTicketAlreadyCancelled,
+		domain.ErrWaitlistTicketsAvailable,
+		domain.ErrWaitlistAlreadyRegistered,
+		domain.ErrTicketTransferPending,
+		domain.ErrTicketTransferNotPending,
+		domain.ErrTicketTransferExpired,
+		domain.ErrTicketTransferNotActive,
+		domain.ErrTicketCredentialNotActive,
+		domain.ErrTicketAlreadyUsed,
+		domain.ErrCheckInTicketCancelled,
+		domain.ErrWebhookDeliveryNotDeadLetter,
+		domain.ErrWaitingRoomNotActive,
+		domain.ErrAdmissionTokenUsed,
+	}
+	paymentErrors = []error{
+		domain.ErrPaymentDeclined,
+	}
+	forbiddenErrors = []error{
+		domain.ErrForbidden,
+		domain.ErrTicketTransferNotHolder,
+		domain.ErrTicketTransferInvalidToken,
+		domain.ErrTicketCredentialInvalid,
+		domain.ErrTicketCredentialUnknownKey,
+		domain.ErrTicketCredentialExpired,
+		domain.ErrTicketCredentialNotYetValid,
+		domain.ErrTicketCredentialRevoked,
+		domain.ErrCheckInWrongEvent,
+		domain.ErrCheckInCredentialTicket,
+		domain.ErrCheckInNotInManifest,
+		domain.ErrCheckInManifestExpired,
+		domain.ErrAdmissionTokenRequired,
+		domain.ErrAdmissionTokenInvalid,
+		domain.ErrAdmissionTokenNotYetAdmitted,
+		domain.ErrAdmissionTokenExpired,
+		domain.ErrAdmissionTokenOtherCustomer,
+		domain.ErrSalesPhaseAccessCodeRequired,
+		domain.ErrSalesPhaseInvalidAccessCode,
+		domain.ErrHoldOtherCustomer,
+	}
+	validationErrors = []error{
+		domain.ErrInvalidTicketType,
+		domain.ErrSeatSelectionInvalidQuantity,
+		domain.ErrHoldWithoutSpots,
+		domain.ErrHoldInvalidQuantity,
+		domain.ErrHoldQuantityExceeded,
+		domain.ErrSpotNameRequired,
+		domain.ErrSpotNameLessThanTwo,
+		domain.ErrInvalidSpotNameFirstCharacter,
+		domain.ErrInvalidSpotNameLastCharacter,
+		domain.ErrInvalidSpotNumber,
+		domain.ErrInvalidSpotRowLabel,
+		domain.ErrSpotLayoutInvalidRows,
+		domain.ErrSpotLayoutInvalidSeatsPerRow,
+		domain.ErrSpotLayoutTooManyRows,
+		domain.ErrSpotLayoutTooManySpots,
+		domain.ErrSpotLayoutInvalidSkipRow,
+		domain.ErrSpotLayoutInvalidSeat,
+		domain.ErrSpotLayoutInvalidZone,
+		domain.ErrSpotLayoutUnknownSpot,
+		domain.ErrInvalidSpotAttribute,
+		domain.ErrCompanionSeatWithoutWheelchair,
+		domain.ErrObstructedViewNotAccepted,
+		domain.ErrAttendeeRequired,
+		domain.ErrAttendeeBirthDateRequired,
+		domain.ErrAttendeeInvalidBirthDate,
+		domain.ErrAttendeeBirthDateInTheFuture,
+		domain.ErrAttendeeBelowAgeRating,
+		domain.ErrEventGeneralAdmission,
+		domain.ErrEventSeated,
+		domain.ErrSpotDuplicated,
+		domain.ErrEventInvalidQuantity,
+		domain.ErrEventHasSessions,
+		domain.ErrPurchaseOrderLimitExceeded,
+		domain.ErrPurchaseLimitInvalid,
+		domain.ErrPurchaseLimitWindowRequired,
+		domain.ErrOrderEmailRequired,
+		domain.ErrWaitlistInvalidQuantity,
+		domain.ErrTicketTransferSameHolder,
+		domain.ErrCheckInGateRequired,
+		domain.ErrCheckInScannedInFuture,
+		domain.ErrCheckInInvalidScannedAt,
+		domain.ErrPaymentCardHashRequired,
+		domain.ErrWebhookInvalidURL,
+		domain.ErrWebhookSecretTooShort,
+		domain.ErrWebhookEventTypesRequired,
+		domain.ErrWebhookInvalidEventType,
+		domain.ErrWebhookInvalidDeliveryStatus,
+		domain.ErrWaitingRoomInvalidRate,
+		domain.ErrWaitingRoomInvalidTTL,
+		domain.ErrWaitingRoomSession,
+		domain.ErrCustomerUserRequired,
+		domain.ErrCustomerNameTooLong,
+		domain.ErrOrganizationRequired,
+		domain.ErrOrganizationUnknownPartner,
+		domain.ErrInvalidRating,
+		domain.ErrEventNameRequired,
+		domain.ErrEventInvalidDate,
+		domain.ErrEventInvalidDateFormat,
+		domain.ErrEventCapacityLessEqualZero,
+		domain.ErrEventPriceEqualZero,
+		domain.ErrEventInvalidSalesWindow,
+		domain.ErrRecurrenceInvalidFrequency,
+		domain.ErrRecurrenceInvalidInterval,
+		domain.ErrRecurrenceInvalidWeekday,
+		domain.ErrRecurrenceEndRequired,
+		domain.ErrRecurrenceTooManySessions,
+		domain.ErrRecurrenceNoSessions,
+		domain.ErrSalesPhaseInvalidKind,
+		domain.ErrSalesPhaseDuplicated,
+		domain.ErrSalesPhaseInvalidPeriod,
+		domain.ErrSalesPhaseOverlap,
+		domain.ErrSalesPhaseInvalidPrice,
+		domain.ErrSalesPhaseOutsideSalesWindow,
+		domain.ErrSalesPhaseInvalidQuota,
+		domain.ErrSalesPhaseAccessCodeMissing,
+		domain.ErrSalesPhaseAccessCodeForbidden,
+		domain.ErrVenueNameRequired,
+		domain.ErrVenueInvalidCoordinates,
+		domain.ErrVenueInvalidTimezone,
+		domain.ErrVenueInvalidCapacity,
+		domain.ErrVenueCapacityExceeded,
+		domain.ErrVenueLayoutNameRequired,
+	}
+)
+
+// writeError replies with the status code matching a domain error, falling
+// back to 500 for anything unknown.
+func writeError(w http.ResponseWriter, err error) {
+	status := statusFromError(err)
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func statusFromError(err error) int {
+	switch {
+	case isAny(err, unauthorizedErrors):
+		return http.StatusUnauthorized
+	case isAny(err, notFoundErrors):
+		return http.StatusNotFound
+	case isAny(err, paymentErrors):
+		return http.StatusPaymentRequired
+	case isAny(err, forbiddenErrors):
+		return http.StatusForbidden
+	case isAny(err, conflictErrors):
+		return http.StatusConflict
+	case isAny(err, validationErrors):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/daffc/imersao18/golang/internal/events/usecase"
@@ -12,6 +14,14 @@ type EventsHandler struct {
 	getEventsUseCase  *usecase.GetEventsUseCase
 	listSpotsUseCase  *usecase.ListSpotsUseCase
 	buyTicketsUseCase *usecase.BuyTicketsUseCase
+
+	createEventUseCase   *usecase.CreateEventUseCase
+	generateSpotsUseCase *usecase.GenerateSpotsUseCase
+	bestAvailableUseCase *usecase.BestAvailableUseCase
+
+	setPurchaseLimitsUseCase *usecase.SetPurchaseLimitsUseCase
+	setSalesPhasesUseCase    *usecase.SetSalesPhasesUseCase
+	cancelEventUseCase       *usecase.CancelEventUseCase
 }
 
 func NewEventHandler(
@@ -19,19 +29,33 @@ func NewEventHandler(
 	getEventsUseCase *usecase.GetEventsUseCase,
 	listSpotsUseCase *usecase.ListSpotsUseCase,
 	buyTicketsUseCase *usecase.BuyTicketsUseCase,
+	createEventUseCase *usecase.CreateEventUseCase,
+	generateSpotsUseCase *usecase.GenerateSpotsUseCase,
+	bestAvailableUseCase *usecase.BestAvailableUseCase,
+	setPurchaseLimitsUseCase *usecase.SetPurchaseLimitsUseCase,
+	setSalesPhasesUseCase *usecase.SetSalesPhasesUseCase,
+	cancelEventUseCase *usecase.CancelEventUseCase,
 ) *EventsHandler {
 	return &EventsHandler{
 		listEventsUseCase: listEventsUseCase,
 		getEventsUseCase:  getEventsUseCase,
 		listSpotsUseCase:  listSpotsUseCase,
 		buyTicketsUseCase: buyTicketsUseCase,
+
+		createEventUseCase:   createEventUseCase,
+		generateSpotsUseCase: generateSpotsUseCase,
+		bestAvailableUseCase: bestAvailableUseCase,
+
+		setPurchaseLimitsUseCase: setPurchaseLimitsUseCase,
+		setSalesPhasesUseCase:    setSalesPhasesUseCase,
+		cancelEventUseCase:       cancelEventUseCase,
 	}
 }
 
 func (h *EventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
-	output, err := h.listEventsUseCase.Execute()
+	output, err := h.listEventsUseCase.Execute(usecase.ListEventsInputDTO{OrganizationId: organizationId(r)})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -41,10 +65,10 @@ func (h *EventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 
 func (h *EventsHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	eventId := r.PathValue("eventId")
-	input := usecase.GetEventInputDTO{Id: eventId}
+	input := usecase.GetEventInputDTO{OrganizationId: organizationId(r), Id: eventId}
 	output, err := h.getEventsUseCase.Execute(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -54,10 +78,15 @@ func (h *EventsHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 
 func (h *EventsHandler) ListSpots(w http.ResponseWriter, r *http.Request) {
 	eventId := r.PathValue("eventId")
-	input := usecase.ListSpotsInputDTO{EventId: eventId}
+	input := usecase.ListSpotsInputDTO{
+		OrganizationId: organizationId(r),
+		EventId:        eventId,
+		Status:         r.URL.Query().Get("status"),
+		Attributes:     r.URL.Query()["attribute"],
+	}
 	output, err := h.listSpotsUseCase.Execute(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -68,13 +97,137 @@ func (h *EventsHandler) ListSpots(w http.ResponseWriter, r *http.Request) {
 func (h *EventsHandler) BuyTickets(w http.ResponseWriter, r *http.Request) {
 	var input usecase.BuyTicketsInputDTO
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	// A compra pertence ao cliente autenticado, não ao email informado.
+	principal := PrincipalFromContext(r.Context())
+	input.Principal = principal
+	input.Email = principal.Email
+	input.OrganizationId = organizationId(r)
 
 	output, err := h.buyTicketsUseCase.Execute(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// CreateEvent schedules an event at a venue of the organization.
+func (h *EventsHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CreateEventInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+
+	output, err := h.createEventUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *EventsHandler) GenerateSpots(w http.ResponseWriter, r *http.Request) {
+	var input usecase.GenerateSpotsInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.generateSpotsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *EventsHandler) BestAvailable(w http.ResponseWriter, r *http.Request) {
+	var input usecase.BestAvailableInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.bestAvailableUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *EventsHandler) SetPurchaseLimits(w http.ResponseWriter, r *http.Request) {
+	var input usecase.SetPurchaseLimitsInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.setPurchaseLimitsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// SetSalesPhases replaces the presale, general sale and door sale phases
+// of an event.
+func (h *EventsHandler) SetSalesPhases(w http.ResponseWriter, r *http.Request) {
+	var input usecase.SetSalesPhasesInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.setSalesPhasesUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *EventsHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CancelEventInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.cancelEventUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+// MeHandler serves the account of the authenticated customer.
+type MeHandler struct {
+	getCustomerProfileUseCase    *usecase.GetCustomerProfileUseCase
+	updateCustomerProfileUseCase *usecase.UpdateCustomerProfileUseCase
+	listCustomerTicketsUseCase   *usecase.ListCustomerTicketsUseCase
+	listCustomerOrdersUseCase    *usecase.ListCustomerOrdersUseCase
+}
+
+func NewMeHandler(
+	getCustomerProfileUseCase *usecase.GetCustomerProfileUseCase,
+	updateCustomerProfileUseCase *usecase.UpdateCustomerProfileUseCase,
+	listCustomerTicketsUseCase *usecase.ListCustomerTicketsUseCase,
+	listCustomerOrdersUseCase *usecase.ListCustomerOrdersUseCase,
+) *MeHandler {
+	return &MeHandler{
+		getCustomerProfileUseCase:    getCustomerProfileUseCase,
+		updateCustomerProfileUseCase: updateCustomerProfileUseCase,
+		listCustomerTicketsUseCase:   listCustomerTicketsUseCase,
+		listCustomerOrdersUseCase:    listCustomerOrdersUseCase,
+	}
+}
+
+func (h *MeHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetCustomerProfileInputDTO{Principal: PrincipalFromContext(r.Context())}
+	output, err := h.getCustomerProfileUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *MeHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var input usecase.UpdateCustomerProfileInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.Principal = PrincipalFromContext(r.Context())
+
+	output, err := h.updateCustomerProfileUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListTickets lists the tickets held by the customer, split into upcoming
+// and past events.
+func (h *MeHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
+	input := usecase.ListCustomerTicketsInputDTO{Principal: PrincipalFromContext(r.Context())}
+	output, err := h.listCustomerTicketsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListOrders lists the orders placed by the customer, newest first.
+func (h *MeHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	input := usecase.ListCustomerOrdersInputDTO{Principal: PrincipalFromContext(r.Context())}
+	output, err := h.listCustomerOrdersUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type OrdersHandler struct {
+	getOrderTicketsDocumentUseCase *usecase.GetOrderTicketsDocumentUseCase
+}
+
+func NewOrdersHandler(getOrderTicketsDocumentUseCase *usecase.GetOrderTicketsDocumentUseCase) *OrdersHandler {
+	return &OrdersHandler{getOrderTicketsDocumentUseCase: getOrderTicketsDocumentUseCase}
+}
+
+// GetOrderTicketsPDF serves the tickets and receipt of an order as a PDF.
+// ?download=true asks the browser to save it instead of displaying it.
+func (h *OrdersHandler) GetOrderTicketsPDF(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetOrderTicketsDocumentInputDTO{
+		OrganizationId: organizationId(r),
+		OrderId:        r.PathValue("orderId"),
+		Principal:      PrincipalFromContext(r.Context()),
+	}
+	output, err := h.getOrderTicketsDocumentUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	disposition := "inline"
+	if r.URL.Query().Get("download") == "true" {
+		disposition = "attachment"
+	}
+
+	w.Header().Set("Content-Type", output.ContentType)
+	w.Header().Set("Content-Disposition", disposition+`; filename="`+output.FileName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(output.Content)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(output.Content)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type organizationKey struct{}
+
+// ResolveOrganization binds every request to the organization named by the
+// X-Organization header or, without it, serving the request host. The
+// authenticated user gets the roles they have in that organization.
+// Requests to other hosts go on without an organization. It must run after
+// Authenticate.
+func ResolveOrganization(resolveOrganizationUseCase *usecase.ResolveOrganizationUseCase, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		output, err := resolveOrganizationUseCase.Execute(usecase.ResolveOrganizationInputDTO{
+			Slug:      r.Header.Get("X-Organization"),
+			Host:      r.Host,
+			Principal: PrincipalFromContext(r.Context()),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if output.Organization == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), organizationKey{}, output.Organization)
+		if output.Principal != nil {
+			ctx = context.WithValue(ctx, principalKey{}, output.Principal)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// OrganizationFromContext returns the organization of a request, or nil
+// when it is not bound to any.
+func OrganizationFromContext(ctx context.Context) *usecase.OrganizationDTO {
+	organization, _ := ctx.Value(organizationKey{}).(*usecase.OrganizationDTO)
+	return organization
+}
+
+// RequireOrganization rejects requests not bound to an organization.
+func RequireOrganization(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if OrganizationFromContext(r.Context()) == nil {
+			writeError(w, domain.ErrOrganizationRequired)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// organizationId returns the id of the organization of a request passed
+// through RequireOrganization.
+func organizationId(r *http.Request) string {
+	return OrganizationFromContext(r.Context()).Id
+}
+
+// GetOrganization returns the name and branding of the organization of the
+// request, for storefronts to render.
+func GetOrganization(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OrganizationFromContext(r.Context()))
+}
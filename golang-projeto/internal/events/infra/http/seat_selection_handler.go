@@ -0,0 +1,302 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/daffc/imersao18/golang/internal/events/infra/realtime"
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	seatSocketWriteTimeout = 10 * time.Second
+	seatSocketPongTimeout  = 60 * time.Second
+	seatSocketPingInterval = 25 * time.Second
+	seatSocketMaxMessage   = 4096
+	seatSocketMaxSelection = 10
+	seatSocketBufferSize   = 64
+)
+
+var (
+	errSeatSocketUnknownMessage  = errors.New("unknown message type")
+	errSeatSocketSelectionTooBig = errors.New("too many spots selected")
+)
+
+// seatClientMessage is sent by clients over the seat selection socket:
+//
+//	{"type": "select", "spots": ["A1", "A2"]}
+//	{"type": "hold", "spots": ["A1", "A2"], "ticket_type": "full"}
+//	{"type": "release", "hold_id": "..."}
+type seatClientMessage struct {
+	Type       string   `json:"type"`
+	Spots      []string `json:"spots"`
+	TicketType string   `json:"ticket_type"`
+	HoldId     string   `json:"hold_id"`
+}
+
+// seatServerMessage is sent to clients. Type is one of welcome, selection,
+// spot, held, released, conflict or error.
+type seatServerMessage struct {
+	Type       string                 `json:"type"`
+	ClientId   string                 `json:"client_id,omitempty"`
+	Selections []realtime.Selection   `json:"selections,omitempty"`
+	Selection  *realtime.Selection    `json:"selection,omitempty"`
+	Spot       *usecase.SpotChangeDTO `json:"spot,omitempty"`
+	Hold       *usecase.HoldDTO       `json:"hold,omitempty"`
+	Spots      []string               `json:"spots,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+type SeatSelectionHandler struct {
+	watchSpotsUseCase  *usecase.WatchSpotsUseCase
+	holdSpotsUseCase   *usecase.HoldSpotsUseCase
+	releaseHoldUseCase *usecase.ReleaseHoldUseCase
+	room               *realtime.SelectionRoom
+	upgrader           websocket.Upgrader
+}
+
+// NewSeatSelectionHandler creates the seat selection socket. Browsers on
+// allowedOrigins may connect besides the API own origin.
+func NewSeatSelectionHandler(
+	watchSpotsUseCase *usecase.WatchSpotsUseCase,
+	holdSpotsUseCase *usecase.HoldSpotsUseCase,
+	releaseHoldUseCase *usecase.ReleaseHoldUseCase,
+	room *realtime.SelectionRoom,
+	allowedOrigins []string,
+) *SeatSelectionHandler {
+	h := &SeatSelectionHandler{
+		watchSpotsUseCase:  watchSpotsUseCase,
+		holdSpotsUseCase:   holdSpotsUseCase,
+		releaseHoldUseCase: releaseHoldUseCase,
+		room:               room,
+	}
+	if len(allowedOrigins) > 0 {
+		h.upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || slices.Contains(allowedOrigins, origin)
+		}
+	}
+	return h
+}
+
+// seatSession is one client connected to the socket of an event.
+type seatSession struct {
+	organizationId string
+	eventId        string
+	clientId       string
+	out            chan seatServerMessage
+	cancel         context.CancelFunc
+
+	mu        sync.Mutex
+	selection []string
+	holds     []string
+}
+
+// send queues a message without blocking; a client that does not keep up
+// is disconnected.
+func (s *seatSession) send(message seatServerMessage) {
+	select {
+	case s.out <- message:
+	default:
+		s.cancel()
+	}
+}
+
+// SeatSelection upgrades to a WebSocket where clients of an event share
+// their seat selections, see spot changes live and hold or release spots.
+// Holds placed over the socket outlive it: they are bought with POST
+// /checkout or expire.
+func (h *SeatSelectionHandler) SeatSelection(w http.ResponseWriter, r *http.Request) {
+	input := usecase.WatchSpotsInputDTO{OrganizationId: organizationId(r), EventId: r.PathValue("eventId")}
+	watch, err := h.watchSpotsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer watch.Close()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	session := &seatSession{
+		organizationId: input.OrganizationId,
+		eventId:        input.EventId,
+		clientId:       uuid.New().String(),
+		out:            make(chan seatServerMessage, seatSocketBufferSize),
+		cancel:         cancel,
+	}
+
+	selections := h.room.Join(session.eventId, session.clientId, func(selection realtime.Selection) {
+		session.send(seatServerMessage{Type: "selection", Selection: &selection})
+	})
+	defer h.room.Leave(session.eventId, session.clientId)
+	session.send(seatServerMessage{Type: "welcome", ClientId: session.clientId, Selections: selections})
+
+	go h.writeLoop(ctx, conn, session)
+	go h.watchLoop(ctx, watch.Changes, session)
+
+	// Fechando a conexão quando o contexto acaba desbloqueia a leitura.
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	conn.SetReadLimit(seatSocketMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(seatSocketPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(seatSocketPongTimeout))
+	})
+	for {
+		var message seatClientMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		h.handleMessage(session, message)
+	}
+}
+
+func (h *SeatSelectionHandler) writeLoop(ctx context.Context, conn *websocket.Conn, session *seatSession) {
+	ping := time.NewTicker(seatSocketPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(seatSocketWriteTimeout)); err != nil {
+				session.cancel()
+				return
+			}
+		case message := <-session.out:
+			conn.SetWriteDeadline(time.Now().Add(seatSocketWriteTimeout))
+			if err := conn.WriteJSON(message); err != nil {
+				session.cancel()
+				return
+			}
+		}
+	}
+}
+
+// watchLoop forwards spot changes and warns the client when a spot it
+// selected was held or sold by someone else.
+func (h *SeatSelectionHandler) watchLoop(ctx context.Context, changes <-chan domain.SpotChange, session *seatSession) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				session.cancel()
+				return
+			}
+			dto := usecase.NewSpotChangeDTO(change)
+			session.send(seatServerMessage{Type: "spot", Spot: &dto})
+
+			if change.Kind == domain.SpotChangeReleased {
+				continue
+			}
+			session.mu.Lock()
+			selected := slices.Contains(session.selection, change.SpotName)
+			if selected {
+				session.selection = slices.DeleteFunc(slices.Clone(session.selection), func(spot string) bool { return spot == change.SpotName })
+			}
+			selection := session.selection
+			session.mu.Unlock()
+
+			if selected {
+				err := domain.ErrSpotHeld
+				if change.Kind == domain.SpotChangeSold {
+					err = domain.ErrSpotAlreadyReserved
+				}
+				session.send(seatServerMessage{Type: "conflict", Spots: []string{change.SpotName}, Error: err.Error()})
+				h.room.Select(session.eventId, session.clientId, selection)
+			}
+		}
+	}
+}
+
+func (h *SeatSelectionHandler) handleMessage(session *seatSession, message seatClientMessage) {
+	switch message.Type {
+	case "select":
+		if len(message.Spots) > seatSocketMaxSelection {
+			session.send(seatServerMessage{Type: "error", Error: errSeatSocketSelectionTooBig.Error()})
+			return
+		}
+		session.mu.Lock()
+		session.selection = message.Spots
+		session.mu.Unlock()
+		h.room.Select(session.eventId, session.clientId, message.Spots)
+
+	case "hold":
+		// Os lugares deixam de estar apenas selecionados, para que o aviso
+		// da própria retenção não seja tratado como conflito.
+		session.mu.Lock()
+		session.selection = slices.DeleteFunc(slices.Clone(session.selection), func(spot string) bool {
+			return slices.Contains(message.Spots, spot)
+		})
+		selection := session.selection
+		session.mu.Unlock()
+		h.room.Select(session.eventId, session.clientId, selection)
+
+		hold, err := h.holdSpotsUseCase.Execute(usecase.HoldSpotsInputDTO{
+			OrganizationId: session.organizationId,
+			EventId:        session.eventId,
+			Spots:          message.Spots,
+			TicketType:     message.TicketType,
+		})
+		if err != nil {
+			h.sendError(session, message.Spots, err)
+			return
+		}
+		session.mu.Lock()
+		session.holds = append(session.holds, hold.Id)
+		session.mu.Unlock()
+		session.send(seatServerMessage{Type: "held", Hold: hold})
+
+	case "release":
+		// Apenas holds feitos nesta conexão podem ser liberados por ela.
+		session.mu.Lock()
+		owned := slices.Contains(session.holds, message.HoldId)
+		session.mu.Unlock()
+		if !owned {
+			h.sendError(session, nil, domain.ErrHoldNotFound)
+			return
+		}
+
+		hold, err := h.releaseHoldUseCase.Execute(usecase.ReleaseHoldInputDTO{EventId: session.eventId, HoldId: message.HoldId})
+		if err != nil {
+			h.sendError(session, nil, err)
+			return
+		}
+		session.mu.Lock()
+		session.holds = slices.DeleteFunc(session.holds, func(id string) bool { return id == hold.Id })
+		session.mu.Unlock()
+		session.send(seatServerMessage{Type: "released", Hold: hold})
+
+	default:
+		session.send(seatServerMessage{Type: "error", Error: errSeatSocketUnknownMessage.Error()})
+	}
+}
+
+// sendError reports a failed request, as a conflict when the spots were
+// taken by someone else.
+func (h *SeatSelectionHandler) sendError(session *seatSession, spots []string, err error) {
+	messageType := "error"
+	if statusFromError(err) == http.StatusConflict {
+		messageType = "conflict"
+	}
+	session.send(seatServerMessage{Type: messageType, Spots: spots, Error: err.Error()})
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type SpotsStreamHandler struct {
+	watchSpotsUseCase *usecase.WatchSpotsUseCase
+	heartbeat         time.Duration
+}
+
+func NewSpotsStreamHandler(watchSpotsUseCase *usecase.WatchSpotsUseCase, heartbeat time.Duration) *SpotsStreamHandler {
+	return &SpotsStreamHandler{watchSpotsUseCase: watchSpotsUseCase, heartbeat: heartbeat}
+}
+
+// StreamSpots pushes spot status changes of an event as Server-Sent Events.
+// Every change is a "spot" message whose id resumes the stream through the
+// Last-Event-ID header. A "reset" message means changes were lost and the
+// spots must be reloaded from GET /events/{eventId}/spots.
+func (h *SpotsStreamHandler) StreamSpots(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	input := usecase.WatchSpotsInputDTO{
+		OrganizationId: organizationId(r),
+		EventId:        r.PathValue("eventId"),
+		LastEventId:    r.Header.Get("Last-Event-ID"),
+	}
+	output, err := h.watchSpotsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer output.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if output.Reset {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, change := range output.Missed {
+		writeSpotChange(w, change)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case change, ok := <-output.Changes:
+			// O hub fecha o canal de clientes lentos; o navegador reconecta
+			// e retoma a partir do último id recebido.
+			if !ok {
+				return
+			}
+			writeSpotChange(w, usecase.NewSpotChangeDTO(change))
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSpotChange(w http.ResponseWriter, change usecase.SpotChangeDTO) {
+	data, _ := json.Marshal(change)
+	fmt.Fprintf(w, "id: %d\nevent: spot\ndata: %s\n\n", change.Seq, data)
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type TicketsHandler struct {
+	cancelTicketUseCase         *usecase.CancelTicketUseCase
+	transferTicketUseCase       *usecase.TransferTicketUseCase
+	acceptTicketTransferUseCase *usecase.AcceptTicketTransferUseCase
+	listTicketHoldersUseCase    *usecase.ListTicketHoldersUseCase
+	getTicketCredentialUseCase  *usecase.GetTicketCredentialUseCase
+}
+
+func NewTicketsHandler(
+	cancelTicketUseCase *usecase.CancelTicketUseCase,
+	transferTicketUseCase *usecase.TransferTicketUseCase,
+	acceptTicketTransferUseCase *usecase.AcceptTicketTransferUseCase,
+	listTicketHoldersUseCase *usecase.ListTicketHoldersUseCase,
+	getTicketCredentialUseCase *usecase.GetTicketCredentialUseCase,
+) *TicketsHandler {
+	return &TicketsHandler{
+		cancelTicketUseCase:         cancelTicketUseCase,
+		transferTicketUseCase:       transferTicketUseCase,
+		acceptTicketTransferUseCase: acceptTicketTransferUseCase,
+		listTicketHoldersUseCase:    listTicketHoldersUseCase,
+		getTicketCredentialUseCase:  getTicketCredentialUseCase,
+	}
+}
+
+func (h *TicketsHandler) CancelTicket(w http.ResponseWriter, r *http.Request) {
+	input := usecase.CancelTicketInputDTO{
+		OrganizationId: organizationId(r),
+		TicketId:       r.PathValue("ticketId"),
+		Principal:      PrincipalFromContext(r.Context()),
+	}
+	output, err := h.cancelTicketUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *TicketsHandler) TransferTicket(w http.ResponseWriter, r *http.Request) {
+	var input usecase.TransferTicketInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.TicketId = r.PathValue("ticketId")
+	input.FromEmail = PrincipalFromContext(r.Context()).Email
+
+	output, err := h.transferTicketUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *TicketsHandler) AcceptTicketTransfer(w http.ResponseWriter, r *http.Request) {
+	var input usecase.AcceptTicketTransferInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+
+	output, err := h.acceptTicketTransferUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *TicketsHandler) ListTicketHolders(w http.ResponseWriter, r *http.Request) {
+	input := usecase.ListTicketHoldersInputDTO{OrganizationId: organizationId(r), TicketId: r.PathValue("ticketId")}
+	output, err := h.listTicketHoldersUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetTicketQRCode serves the signed ticket credential as a QR code.
+// ?format= picks png (default), svg or json; ?size= sets the image width in
+// pixels.
+func (h *TicketsHandler) GetTicketQRCode(w http.ResponseWriter, r *http.Request) {
+	size := defaultQRCodeSize
+	if value := r.URL.Query().Get("size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxQRCodeSize {
+			http.Error(w, "invalid size", http.StatusBadRequest)
+			return
+		}
+		size = parsed
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "png" && format != "svg" && format != "json" {
+		http.Error(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+
+	input := usecase.GetTicketCredentialInputDTO{
+		OrganizationId: organizationId(r),
+		TicketId:       r.PathValue("ticketId"),
+		Principal:      PrincipalFromContext(r.Context()),
+	}
+	output, err := h.getTicketCredentialUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var image []byte
+	switch format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(output)
+		return
+	case "svg":
+		image, err = encodeQRCodeSVG(output.Credential, size)
+		w.Header().Set("Content-Type", "image/svg+xml")
+	default:
+		image, err = encodeQRCodePNG(output.Credential, size)
+		w.Header().Set("Content-Type", "image/png")
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(image)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type VenuesHandler struct {
+	createVenueUseCase     *usecase.CreateVenueUseCase
+	addVenueLayoutUseCase  *usecase.AddVenueLayoutUseCase
+	listVenuesUseCase      *usecase.ListVenuesUseCase
+	listVenueEventsUseCase *usecase.ListVenueEventsUseCase
+}
+
+func NewVenuesHandler(
+	createVenueUseCase *usecase.CreateVenueUseCase,
+	addVenueLayoutUseCase *usecase.AddVenueLayoutUseCase,
+	listVenuesUseCase *usecase.ListVenuesUseCase,
+	listVenueEventsUseCase *usecase.ListVenueEventsUseCase,
+) *VenuesHandler {
+	return &VenuesHandler{
+		createVenueUseCase:     createVenueUseCase,
+		addVenueLayoutUseCase:  addVenueLayoutUseCase,
+		listVenuesUseCase:      listVenuesUseCase,
+		listVenueEventsUseCase: listVenueEventsUseCase,
+	}
+}
+
+func (h *VenuesHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CreateVenueInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+
+	output, err := h.createVenueUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *VenuesHandler) AddVenueLayout(w http.ResponseWriter, r *http.Request) {
+	var input usecase.AddVenueLayoutInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.VenueId = r.PathValue("venueId")
+
+	output, err := h.addVenueLayoutUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *VenuesHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listVenuesUseCase.Execute(usecase.ListVenuesInputDTO{OrganizationId: organizationId(r)})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListVenueEvents lists the upcoming events of a venue.
+func (h *VenuesHandler) ListVenueEvents(w http.ResponseWriter, r *http.Request) {
+	input := usecase.ListVenueEventsInputDTO{
+		OrganizationId: organizationId(r),
+		VenueId:        r.PathValue("venueId"),
+	}
+	output, err := h.listVenueEventsUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+// AdmissionTokenHeader carries the waiting room admission token at
+// checkout.
+const AdmissionTokenHeader = "X-Admission-Token"
+
+const maxCheckoutBodySize = 1 << 20
+
+type WaitingRoomHandler struct {
+	configureWaitingRoomUseCase *usecase.ConfigureWaitingRoomUseCase
+	getWaitingRoomUseCase       *usecase.GetWaitingRoomUseCase
+	joinWaitingRoomUseCase      *usecase.JoinWaitingRoomUseCase
+	getWaitingRoomEntryUseCase  *usecase.GetWaitingRoomEntryUseCase
+	validateAdmissionUseCase    *usecase.ValidateAdmissionUseCase
+	restoreAdmissionUseCase     *usecase.RestoreAdmissionUseCase
+}
+
+func NewWaitingRoomHandler(
+	configureWaitingRoomUseCase *usecase.ConfigureWaitingRoomUseCase,
+	getWaitingRoomUseCase *usecase.GetWaitingRoomUseCase,
+	joinWaitingRoomUseCase *usecase.JoinWaitingRoomUseCase,
+	getWaitingRoomEntryUseCase *usecase.GetWaitingRoomEntryUseCase,
+	validateAdmissionUseCase *usecase.ValidateAdmissionUseCase,
+	restoreAdmissionUseCase *usecase.RestoreAdmissionUseCase,
+) *WaitingRoomHandler {
+	return &WaitingRoomHandler{
+		configureWaitingRoomUseCase: configureWaitingRoomUseCase,
+		getWaitingRoomUseCase:       getWaitingRoomUseCase,
+		joinWaitingRoomUseCase:      joinWaitingRoomUseCase,
+		getWaitingRoomEntryUseCase:  getWaitingRoomEntryUseCase,
+		validateAdmissionUseCase:    validateAdmissionUseCase,
+		restoreAdmissionUseCase:     restoreAdmissionUseCase,
+	}
+}
+
+func (h *WaitingRoomHandler) ConfigureWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	var input usecase.ConfigureWaitingRoomInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.configureWaitingRoomUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *WaitingRoomHandler) GetWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetWaitingRoomInputDTO{OrganizationId: organizationId(r), EventId: r.PathValue("eventId")}
+	output, err := h.getWaitingRoomUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *WaitingRoomHandler) JoinWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	input := usecase.JoinWaitingRoomInputDTO{OrganizationId: organizationId(r), EventId: r.PathValue("eventId")}
+	output, err := h.joinWaitingRoomUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetWaitingRoomEntry reports the position and estimated wait of a queued
+// customer. Clients should poll it every few seconds until admitted.
+func (h *WaitingRoomHandler) GetWaitingRoomEntry(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetWaitingRoomEntryInputDTO{
+		OrganizationId: organizationId(r),
+		EventId:        r.PathValue("eventId"),
+		Token:          r.PathValue("token"),
+	}
+	output, err := h.getWaitingRoomEntryUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if output.EstimatedWaitSeconds > 0 {
+		w.Header().Set("Retry-After", "5")
+	}
+	json.NewEncoder(w).Encode(output)
+}
+
+// RequireAdmission guards checkout: while the waiting room of the event in
+// the request body, or of its recurring event for a session, is active,
+// only requests with an admitted token in the X-Admission-Token header go
+// through. The token is used up by the
+// checkout, and given back only if the checkout fails.
+func (h *WaitingRoomHandler) RequireAdmission(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCheckoutBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var checkout struct {
+			EventId string `json:"event_id"`
+		}
+		if err := json.Unmarshal(body, &checkout); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		input := usecase.ValidateAdmissionInputDTO{
+			OrganizationId: organizationId(r),
+			EventId:        checkout.EventId,
+			Token:          r.Header.Get(AdmissionTokenHeader),
+			Principal:      PrincipalFromContext(r.Context()),
+		}
+		admission, err := h.validateAdmissionUseCase.Execute(input)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// O corpo já lido é devolvido para o handler de checkout.
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+
+		// Um checkout que falhou devolve o token para uma nova tentativa.
+		if admission.ConsumedToken != "" && recorder.status >= http.StatusBadRequest {
+			restore := usecase.RestoreAdmissionInputDTO{Token: admission.ConsumedToken}
+			if err := h.restoreAdmissionUseCase.Execute(restore); err != nil {
+				log.Printf("restore admission token for event %s: %v", checkout.EventId, err)
+			}
+		}
+	}
+}
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type WaitlistHandler struct {
+	joinWaitlistUseCase *usecase.JoinWaitlistUseCase
+}
+
+func NewWaitlistHandler(joinWaitlistUseCase *usecase.JoinWaitlistUseCase) *WaitlistHandler {
+	return &WaitlistHandler{joinWaitlistUseCase: joinWaitlistUseCase}
+}
+
+func (h *WaitlistHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	var input usecase.JoinWaitlistInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+	input.EventId = r.PathValue("eventId")
+
+	output, err := h.joinWaitlistUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/daffc/imersao18/golang/internal/events/usecase"
+)
+
+type WebhooksHandler struct {
+	registerWebhookUseCase       *usecase.RegisterWebhookUseCase
+	listWebhookDeliveriesUseCase *usecase.ListWebhookDeliveriesUseCase
+	retryWebhookDeliveryUseCase  *usecase.RetryWebhookDeliveryUseCase
+}
+
+func NewWebhooksHandler(
+	registerWebhookUseCase *usecase.RegisterWebhookUseCase,
+	listWebhookDeliveriesUseCase *usecase.ListWebhookDeliveriesUseCase,
+	retryWebhookDeliveryUseCase *usecase.RetryWebhookDeliveryUseCase,
+) *WebhooksHandler {
+	return &WebhooksHandler{
+		registerWebhookUseCase:       registerWebhookUseCase,
+		listWebhookDeliveriesUseCase: listWebhookDeliveriesUseCase,
+		retryWebhookDeliveryUseCase:  retryWebhookDeliveryUseCase,
+	}
+}
+
+func (h *WebhooksHandler) RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	var input usecase.RegisterWebhookInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.OrganizationId = organizationId(r)
+
+	output, err := h.registerWebhookUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListWebhookDeliveries is the delivery log of a webhook, filtered by
+// ?status= and capped by ?limit=.
+func (h *WebhooksHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	h.listDeliveries(w, r, r.URL.Query().Get("status"))
+}
+
+// ListWebhookDeadLetters lists the deliveries that ran out of attempts.
+func (h *WebhooksHandler) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	h.listDeliveries(w, r, string(domain.WebhookDeliveryDead))
+}
+
+func (h *WebhooksHandler) listDeliveries(w http.ResponseWriter, r *http.Request, status string) {
+	input := usecase.ListWebhookDeliveriesInputDTO{
+		OrganizationId: organizationId(r),
+		WebhookId:      r.PathValue("webhookId"),
+		Status:         status,
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		if input.Limit, err = strconv.Atoi(limit); err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	output, err := h.listWebhookDeliveriesUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *WebhooksHandler) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	input := usecase.RetryWebhookDeliveryInputDTO{
+		OrganizationId: organizationId(r),
+		WebhookId:      r.PathValue("webhookId"),
+		DeliveryId:     r.PathValue("deliveryId"),
+	}
+	output, err := h.retryWebhookDeliveryUseCase.Execute(input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// LogPublisher writes domain events to the application log.
+type LogPublisher struct{}
+
+func NewLogPublisher() domain.DomainEventPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(event domain.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	log.Printf("domain event %s: %s", event.Name, body)
+	return nil
+}
+
+// WebhookPublisher POSTs each domain event as JSON to a single URL. Any
+// response other than 2xx is a failure, and the event is retried by the
+// relay.
+type WebhookPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookPublisher(url string) domain.DomainEventPublisher {
+	return &WebhookPublisher{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (p *WebhookPublisher) Publish(event domain.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", event.Id)
+	req.Header.Set("X-Event-Name", event.Name)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s answered %s", p.URL, resp.Status)
+	}
+	return nil
+}
+
+// AllDomainEvents subscribes a Bus handler to every event.
+const AllDomainEvents = "*"
+
+// Bus is an in-process publisher: handlers subscribed to an event name run
+// synchronously when it is published. Publish fails if any handler fails,
+// so the relay retries the event and handlers must be idempotent.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(domain.DomainEvent) error
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]func(domain.DomainEvent) error)}
+}
+
+// Subscribe registers handler for events named name, or for all events
+// when name is AllDomainEvents.
+func (b *Bus) Subscribe(name string, handler func(domain.DomainEvent) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *Bus) Publish(event domain.DomainEvent) error {
+	b.mu.RLock()
+	var handlers []func(domain.DomainEvent) error
+	handlers = append(handlers, b.handlers[event.Name]...)
+	handlers = append(handlers, b.handlers[AllDomainEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,101 @@
+package messaging
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// HTTPWebhookSender posts webhook deliveries to integrator endpoints. Any
+// response other than 2xx counts as a failure, redirects included.
+//
+// Endpoints are chosen by tenants, so the sender only speaks https, never
+// goes through a proxy and refuses to connect to internal addresses once
+// the host is resolved, which also covers DNS answers changing after the
+// subscription was registered.
+type HTTPWebhookSender struct {
+	Client *http.Client
+}
+
+var (
+	errWebhookSchemeNotAllowed  = errors.New("webhook endpoints must use https")
+	errWebhookAddressNotAllowed = errors.New("webhook endpoint resolves to an internal address")
+)
+
+func NewHTTPWebhookSender(timeout time.Duration) domain.WebhookSender {
+	dialer := &net.Dialer{Timeout: timeout, Control: refuseInternalAddresses}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &HTTPWebhookSender{Client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// refuseInternalAddresses runs before each connection, with the address
+// already resolved. The address is left out of the error, which ends up in
+// the delivery log seen by the tenant.
+func refuseInternalAddresses(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !domain.IsPublicAddress(ip) {
+		return errWebhookAddressNotAllowed
+	}
+	return nil
+}
+
+func (s *HTTPWebhookSender) Send(url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	if req.URL.Scheme != "https" {
+		return 0, errWebhookSchemeNotAllowed
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "imersao18-webhooks")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Lendo o corpo para que a conexão possa ser reaproveitada.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &WebhookStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return resp.StatusCode, nil
+}
+
+// WebhookStatusError is returned when an endpoint answers with a non-2xx
+// status.
+type WebhookStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *WebhookStatusError) Error() string {
+	return "webhook endpoint answered " + e.Status
+}
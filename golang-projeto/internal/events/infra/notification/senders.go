@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/google/uuid"
+)
+
+// SMTPSender delivers notifications through an SMTP server. Auth may be nil
+// for servers that accept unauthenticated mail, such as local relays.
+type SMTPSender struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+}
+
+func NewSMTPSender(addr, username, password, from string) domain.EmailSender {
+	sender := &SMTPSender{Addr: addr, From: from}
+	if username != "" {
+		host, _, _ := strings.Cut(addr, ":")
+		sender.Auth = smtp.PlainAuth("", username, password, host)
+	}
+	return sender
+}
+
+func (s *SMTPSender) Send(to, subject, body string) error {
+	return smtp.SendMail(s.Addr, s.Auth, s.From, []string{to}, formatMessage(s.From, to, subject, body))
+}
+
+// LogSender writes notifications to the application log instead of sending
+// them. Meant for development.
+type LogSender struct{}
+
+func NewLogSender() domain.EmailSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(to, subject, body string) error {
+	log.Printf("email to %s: %s\n%s", to, subject, body)
+	return nil
+}
+
+// FileSender saves each notification as an .eml file in Dir, which most
+// mail clients can open. Meant for development.
+type FileSender struct {
+	Dir  string
+	From string
+}
+
+func NewFileSender(dir, from string) (domain.EmailSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSender{Dir: dir, From: from}, nil
+}
+
+func (s *FileSender) Send(to, subject, body string) error {
+	name := time.Now().UTC().Format("20060102T150405") + "-" + uuid.New().String() + ".eml"
+	return os.WriteFile(filepath.Join(s.Dir, name), formatMessage(s.From, to, subject, body), 0o644)
+}
+
+func formatMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
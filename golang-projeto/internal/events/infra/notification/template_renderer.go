@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+const displayDate = "02/01/2006 15:04 MST"
+
+// TemplateRenderer renders notifications from the text/template files in
+// templates/. Each kind has its own file defining a "subject" and a "body"
+// template; layout.tmpl holds the blocks shared by all of them.
+type TemplateRenderer struct {
+	templates map[domain.NotificationKind]*template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format(displayDate)
+	},
+	"price": func(price float64) string {
+		return fmt.Sprintf("R$ %.2f", price)
+	},
+	"spot": func(ticket domain.Ticket) string {
+		if ticket.Spot == nil {
+			return "General admission"
+		}
+		if ticket.Spot.Zone != "" {
+			return ticket.Spot.Name + " (" + ticket.Spot.Zone + ")"
+		}
+		return ticket.Spot.Name
+	},
+	"ticketType": func(ticketType domain.TicketType) string {
+		if ticketType == domain.TicketTypeHalf {
+			return "Half"
+		}
+		return "Full"
+	},
+}
+
+func NewTemplateRenderer() (domain.NotificationRenderer, error) {
+	kinds := []domain.NotificationKind{
+		domain.NotificationPurchaseConfirmation,
+		domain.NotificationTicketCancelled,
+		domain.NotificationEventReminder,
+		domain.NotificationEventCancelled,
+	}
+
+	r := &TemplateRenderer{templates: make(map[domain.NotificationKind]*template.Template, len(kinds))}
+	for _, kind := range kinds {
+		t, err := template.New(string(kind)).Funcs(templateFuncs).ParseFS(templateFiles, "templates/layout.tmpl", "templates/"+string(kind)+".tmpl")
+		if err != nil {
+			return nil, err
+		}
+		r.templates[kind] = t
+	}
+	return r, nil
+}
+
+func (r *TemplateRenderer) Render(kind domain.NotificationKind, data *domain.NotificationData) (string, string, error) {
+	t, ok := r.templates[kind]
+	if !ok {
+		return "", "", domain.ErrNotificationInvalidKind
+	}
+
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
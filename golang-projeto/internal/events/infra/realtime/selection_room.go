@@ -0,0 +1,84 @@
+package realtime
+
+import (
+	"slices"
+	"sync"
+)
+
+// Selection is the set of spots a client is looking at before holding
+// them. Selections are not reservations: several clients may select the
+// same spot, and they are forgotten when the client disconnects.
+type Selection struct {
+	ClientId string   `json:"client_id"`
+	Spots    []string `json:"spots"`
+}
+
+// SelectionRoom shares the in-progress selections of the clients connected
+// to the same event.
+type SelectionRoom struct {
+	mu     sync.Mutex
+	events map[string]map[string]*selectionMember
+}
+
+type selectionMember struct {
+	spots  []string
+	notify func(Selection)
+}
+
+func NewSelectionRoom() *SelectionRoom {
+	return &SelectionRoom{events: make(map[string]map[string]*selectionMember)}
+}
+
+// Join adds a client to the room of an event and returns the selections of
+// the other clients. notify is called with every later change of another
+// client's selection and must not block.
+func (r *SelectionRoom) Join(eventId, clientId string, notify func(Selection)) []Selection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, ok := r.events[eventId]
+	if !ok {
+		members = make(map[string]*selectionMember)
+		r.events[eventId] = members
+	}
+
+	var selections []Selection
+	for id, member := range members {
+		if len(member.spots) > 0 {
+			selections = append(selections, Selection{ClientId: id, Spots: member.spots})
+		}
+	}
+	members[clientId] = &selectionMember{notify: notify}
+	return selections
+}
+
+// Leave removes a client, clearing its selection for the others.
+func (r *SelectionRoom) Leave(eventId, clientId string) {
+	r.Select(eventId, clientId, nil)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.events[eventId], clientId)
+	if len(r.events[eventId]) == 0 {
+		delete(r.events, eventId)
+	}
+}
+
+// Select replaces the selection of a client and tells the other clients.
+func (r *SelectionRoom) Select(eventId, clientId string, spots []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	member, ok := r.events[eventId][clientId]
+	if !ok || slices.Equal(member.spots, spots) {
+		return
+	}
+	member.spots = spots
+
+	selection := Selection{ClientId: clientId, Spots: spots}
+	for id, other := range r.events[eventId] {
+		if id != clientId {
+			other.notify(selection)
+		}
+	}
+}
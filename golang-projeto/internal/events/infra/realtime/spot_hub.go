@@ -0,0 +1,118 @@
+package realtime
+
+import (
+	"sync"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// SpotHub is an in-process SpotAvailabilityHub. It keeps the last
+// historySize changes of each watched event so reconnecting clients can
+// resume, and drops subscribers that do not keep up instead of blocking
+// publishers. An event stops being tracked once its last subscriber
+// leaves; clients resuming after that are reset.
+type SpotHub struct {
+	mu          sync.Mutex
+	historySize int
+	bufferSize  int
+	topics      map[string]*spotTopic
+}
+
+type spotTopic struct {
+	seq         uint64
+	history     []domain.SpotChange
+	subscribers map[chan domain.SpotChange]struct{}
+}
+
+func NewSpotHub(historySize, bufferSize int) *SpotHub {
+	return &SpotHub{
+		historySize: historySize,
+		bufferSize:  bufferSize,
+		topics:      make(map[string]*spotTopic),
+	}
+}
+
+func (h *SpotHub) topic(eventId string) *spotTopic {
+	topic, ok := h.topics[eventId]
+	if !ok {
+		topic = &spotTopic{subscribers: make(map[chan domain.SpotChange]struct{})}
+		h.topics[eventId] = topic
+	}
+	return topic
+}
+
+func (h *SpotHub) Publish(eventId string, changes ...domain.SpotChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Sem assinantes não há quem retome as mudanças.
+	topic, ok := h.topics[eventId]
+	if !ok {
+		return
+	}
+	for _, change := range changes {
+		topic.seq++
+		change.Seq = topic.seq
+		topic.history = append(topic.history, change)
+		if len(topic.history) > h.historySize {
+			topic.history = topic.history[len(topic.history)-h.historySize:]
+		}
+
+		for ch := range topic.subscribers {
+			select {
+			case ch <- change:
+			default:
+				// Assinante lento: é desconectado e retoma pelo Last-Event-ID.
+				h.unsubscribe(eventId, topic, ch)
+			}
+		}
+	}
+}
+
+// unsubscribe closes ch and forgets the topic once it has no subscribers
+// left. Callers hold h.mu.
+func (h *SpotHub) unsubscribe(eventId string, topic *spotTopic, ch chan domain.SpotChange) {
+	if _, ok := topic.subscribers[ch]; !ok {
+		return
+	}
+	delete(topic.subscribers, ch)
+	close(ch)
+	if len(topic.subscribers) == 0 && h.topics[eventId] == topic {
+		delete(h.topics, eventId)
+	}
+}
+
+func (h *SpotHub) Subscribe(eventId string, lastSeq uint64) *domain.SpotSubscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	topic := h.topic(eventId)
+	subscription := &domain.SpotSubscription{}
+	if lastSeq > 0 {
+		// Um Last-Event-ID à frente do hub vem de antes de um reinício; um
+		// anterior ao histórico guardado perdeu mudanças.
+		oldest := topic.seq + 1
+		if len(topic.history) > 0 {
+			oldest = topic.history[0].Seq
+		}
+		if lastSeq > topic.seq || lastSeq+1 < oldest {
+			subscription.Reset = true
+		} else {
+			for _, change := range topic.history {
+				if change.Seq > lastSeq {
+					subscription.Missed = append(subscription.Missed, change)
+				}
+			}
+		}
+	}
+
+	ch := make(chan domain.SpotChange, h.bufferSize)
+	topic.subscribers[ch] = struct{}{}
+	subscription.Changes = ch
+	subscription.Close = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.unsubscribe(eventId, topic, ch)
+	}
+	return subscription
+}
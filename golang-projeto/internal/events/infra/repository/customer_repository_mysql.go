@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlCustomerRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlCustomerRepository creates a new MySQL customer repository.
+func NewMysqlCustomerRepository(db *sql.DB) (domain.CustomerRepository, error) {
+	return &mysqlCustomerRepository{db: db}, nil
+}
+
+const customerColumns = `id, user_id, name, emails, preferred_ticket_type, preferred_zone, accept_obstructed_view, marketing_emails, created_at, updated_at`
+
+// customerEventColumns are the event details listed with the tickets and
+// orders of a customer, read from events e.
+const customerEventColumns = `e.id, e.name, e.location, e.organization, e.date, e.timezone, e.image_url, e.status`
+
+// CreateCustomer inserts a new customer into the database.
+func (r *mysqlCustomerRepository) CreateCustomer(customer *domain.Customer) error {
+	emails, err := json.Marshal(customer.Emails)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`
+		INSERT INTO customers (`+customerColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, customer.Id, customer.UserId, customer.Name, emails,
+		customer.Preferences.TicketType, customer.Preferences.Zone, customer.Preferences.AcceptObstructedView, customer.Preferences.MarketingEmails,
+		customer.CreatedAt.UTC().Format(dateTimeLayout), customer.UpdatedAt.UTC().Format(dateTimeLayout),
+	)
+	return err
+}
+
+// UpdateCustomer stores the profile, emails and preferences of a customer.
+func (r *mysqlCustomerRepository) UpdateCustomer(customer *domain.Customer) error {
+	emails, err := json.Marshal(customer.Emails)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`
+		UPDATE customers
+		SET name = ?, emails = ?, preferred_ticket_type = ?, preferred_zone = ?, accept_obstructed_view = ?, marketing_emails = ?, updated_at = ?
+		WHERE id = ?
+	`, customer.Name, emails,
+		customer.Preferences.TicketType, customer.Preferences.Zone, customer.Preferences.AcceptObstructedView, customer.Preferences.MarketingEmails,
+		customer.UpdatedAt.UTC().Format(dateTimeLayout), customer.Id,
+	)
+	return err
+}
+
+// FindCustomerByUserId returns the customer of an authenticated user.
+func (r *mysqlCustomerRepository) FindCustomerByUserId(userId string) (*domain.Customer, error) {
+	query := `
+		SELECT ` + customerColumns + `
+		FROM customers
+		WHERE user_id = ?
+	`
+	var customer domain.Customer
+	var emails []byte
+	var createdAt, updatedAt string
+	err := r.db.QueryRow(query, userId).Scan(
+		&customer.Id, &customer.UserId, &customer.Name, &emails,
+		&customer.Preferences.TicketType, &customer.Preferences.Zone, &customer.Preferences.AcceptObstructedView, &customer.Preferences.MarketingEmails,
+		&createdAt, &updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(emails, &customer.Emails); err != nil {
+		return nil, err
+	}
+	if customer.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if customer.UpdatedAt, err = parseDateTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindCustomerTickets returns the tickets bought by the customer and still
+// held by them, plus those received by transfer at any of their emails,
+// ordered by event date.
+func (r *mysqlCustomerRepository) FindCustomerTickets(customer *domain.Customer) ([]domain.CustomerTicket, error) {
+	args := []any{customer.Id}
+	holderFilter := ""
+	if len(customer.Emails) > 0 {
+		holderFilter = ` OR (t.customer_id = '' AND t.holder_email IN (?` + strings.Repeat(", ?", len(customer.Emails)-1) + `))`
+		for _, email := range customer.Emails {
+			args = append(args, email)
+		}
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+orderTicketColumns+`, `+customerEventColumns+`
+		FROM tickets t
+		JOIN events e ON e.id = t.event_id
+		LEFT JOIN spots s ON s.id = t.spot_id
+		WHERE t.customer_id = ?`+holderFilter+`
+		ORDER BY e.date, e.id, s.name, t.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []domain.CustomerTicket
+	for rows.Next() {
+		var event domain.Event
+		var eventDate string
+		ticket, err := scanOrderTicket(rows, customerEventDest(&event, &eventDate)...)
+		if err != nil {
+			return nil, err
+		}
+		if event.Date, err = parseDateTime(eventDate); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, domain.CustomerTicket{Ticket: *ticket, Event: event})
+	}
+	return tickets, rows.Err()
+}
+
+// FindCustomerOrders returns the orders placed by the customer, including
+// those placed by their user before the account existed.
+func (r *mysqlCustomerRepository) FindCustomerOrders(customer *domain.Customer) ([]domain.CustomerOrder, error) {
+	rows, err := r.db.Query(`
+		SELECT o.id, o.event_id, o.user_id, o.customer_id, o.email, o.total, o.created_at, o.payment_id, o.payment_status,
+			`+customerEventColumns+`
+		FROM orders o
+		JOIN events e ON e.id = o.event_id
+		WHERE o.customer_id = ? OR (o.customer_id = '' AND o.user_id = ?)
+		ORDER BY o.created_at DESC, o.id
+	`, customer.Id, customer.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []domain.CustomerOrder
+	for rows.Next() {
+		var order domain.Order
+		var event domain.Event
+		var createdAt, eventDate string
+		dest := []any{&order.Id, &order.EventId, &order.UserId, &order.CustomerId, &order.Email, &order.Total, &createdAt, &order.PaymentId, &order.PaymentStatus}
+		if err := rows.Scan(append(dest, customerEventDest(&event, &eventDate)...)...); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if order.CreatedAt, err = parseDateTime(createdAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if event.Date, err = parseDateTime(eventDate); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		orders = append(orders, domain.CustomerOrder{Order: order, Event: event})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Carregando os ingressos após fechar a consulta dos pedidos.
+	for i := range orders {
+		if orders[i].Order.Tickets, err = findOrderTickets(r.db, orders[i].Order.Id); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+func customerEventDest(event *domain.Event, date *string) []any {
+	return []any{&event.Id, &event.Name, &event.Location, &event.Organization, date, &event.Timezone, &event.ImageURL, &event.Status}
+}
@@ -2,13 +2,30 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/daffc/imersao18/golang/internal/events/domain"
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// dateTimeLayout and dateLayout are the formats MySQL uses for DATETIME and
+// DATE columns.
+const (
+	dateTimeLayout = "2006-01-02 15:04:05"
+	dateLayout     = "2006-01-02"
+)
+
+// parseDateTime reads a DATETIME column. All times are stored in UTC.
+func parseDateTime(value string) (time.Time, error) {
+	return time.ParseInLocation(dateTimeLayout, value, time.UTC)
+}
+
+// spotsBatchSize is the number of rows sent per INSERT by CreateSpots.
+const spotsBatchSize = 500
+
 type mysqlEventRepository struct {
 	db *sql.DB
 }
@@ -18,18 +35,20 @@ func NewMysqlEventRepository(db *sql.DB) (domain.EventRepository, error) {
 	return &mysqlEventRepository{db: db}, nil
 }
 
-// ListEvents returns all events with their associated spots and tickets.
-func (r *mysqlEventRepository) ListEvents() ([]domain.Event, error) {
+// ListEvents returns the events of an organization with their associated
+// spots and tickets.
+func (r *mysqlEventRepository) ListEvents(organizationId string) ([]domain.Event, error) {
 	query := `
 		SELECT 
-			e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price, e.partner_id,
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
-			t.id, t.event_id, t.spot_id, t.ticket_type, t.price
+			e.id, e.name, e.location, e.venue_id, e.organization_id, e.organization, e.rating, e.date, e.timezone, e.image_url, e.capacity, e.price, e.partner_id, e.seating_mode, e.sold_tickets, e.status, e.parent_id, e.sales_start_at, e.sales_end_at, e.recurrence,
+			s.id, s.event_id, s.name, s.zone, s.attributes, s.status, s.ticket_id, s.hold_id, s.hold_expires_at,
+			t.id, t.event_id, t.spot_id, t.ticket_type, t.price, t.attendee_name, t.attendee_birth_date
 		FROM events e
 		LEFT JOIN spots s ON e.id = s.event_id
-		LEFT JOIN tickets t ON s.id = t.spot_id
+		LEFT JOIN tickets t ON s.id = t.spot_id AND t.status <> 'cancelled'
+		WHERE e.organization_id = ?
 	`
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, organizationId)
 	if err != nil {
 		return nil, err
 	}
@@ -38,16 +57,18 @@ func (r *mysqlEventRepository) ListEvents() ([]domain.Event, error) {
 	eventMap := make(map[string]*domain.Event)
 	spotMap := make(map[string]*domain.Spot)
 	for rows.Next() {
-		var eventId, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotId, spotEventId, spotName, spotStatus, spotTicketId, ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
+		var eventId, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotId, spotEventId, spotName, spotZone, spotAttributes, spotStatus, spotTicketId, spotHoldId, spotHoldExpiresAt, ticketId, ticketEventId, ticketSpotId, ticketType, ticketAttendeeName, ticketAttendeeBirthDate sql.NullString
 		var eventDate sql.NullString
-		var eventCapacity int
+		var eventCapacity, eventSoldTickets int
+		var eventVenueId, eventOrganizationId, eventSeatingMode, eventStatus, eventParentId, eventTimezone string
+		var eventSalesStartAt, eventSalesEndAt, eventRecurrence sql.NullString
 		var eventPrice, ticketPrice sql.NullFloat64
 		var partnerId sql.NullInt32
 
 		err := rows.Scan(
-			&eventId, &eventName, &eventLocation, &eventOrganization, &eventRating, &eventDate, &eventImageURL, &eventCapacity, &eventPrice, &partnerId,
-			&spotId, &spotEventId, &spotName, &spotStatus, &spotTicketId,
-			&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice,
+			&eventId, &eventName, &eventLocation, &eventVenueId, &eventOrganizationId, &eventOrganization, &eventRating, &eventDate, &eventTimezone, &eventImageURL, &eventCapacity, &eventPrice, &partnerId, &eventSeatingMode, &eventSoldTickets, &eventStatus, &eventParentId, &eventSalesStartAt, &eventSalesEndAt, &eventRecurrence,
+			&spotId, &spotEventId, &spotName, &spotZone, &spotAttributes, &spotStatus, &spotTicketId, &spotHoldId, &spotHoldExpiresAt,
+			&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice, &ticketAttendeeName, &ticketAttendeeBirthDate,
 		)
 		if err != nil {
 			return nil, err
@@ -59,23 +80,33 @@ func (r *mysqlEventRepository) ListEvents() ([]domain.Event, error) {
 
 		event, exists := eventMap[eventId.String]
 		if !exists {
-			eventDateParsed, err := time.Parse("2006-01-02 15:04:05", eventDate.String)
+			eventDateParsed, err := parseDateTime(eventDate.String)
 			if err != nil {
 				return nil, err
 			}
 			event = &domain.Event{
-				Id:           eventId.String,
-				Name:         eventName.String,
-				Location:     eventLocation.String,
-				Organization: eventOrganization.String,
-				Rating:       domain.Rating(eventRating.String),
-				Date:         eventDateParsed,
-				ImageURL:     eventImageURL.String,
-				Capacity:     eventCapacity,
-				Price:        eventPrice.Float64,
-				PartnerId:    int(partnerId.Int32),
-				Spots:        []domain.Spot{},
-				Tickets:      []domain.Ticket{},
+				Id:             eventId.String,
+				ParentId:       eventParentId,
+				Name:           eventName.String,
+				Location:       eventLocation.String,
+				VenueId:        eventVenueId,
+				OrganizationId: eventOrganizationId,
+				Organization:   eventOrganization.String,
+				Rating:         domain.Rating(eventRating.String),
+				Date:           eventDateParsed,
+				Timezone:       eventTimezone,
+				ImageURL:       eventImageURL.String,
+				Capacity:       eventCapacity,
+				Price:          eventPrice.Float64,
+				PartnerId:      int(partnerId.Int32),
+				SeatingMode:    domain.SeatingMode(eventSeatingMode),
+				SoldTickets:    eventSoldTickets,
+				Status:         domain.EventStatus(eventStatus),
+				Spots:          []domain.Spot{},
+				Tickets:        []domain.Ticket{},
+			}
+			if err := setEventSchedule(event, eventSalesStartAt, eventSalesEndAt, eventRecurrence); err != nil {
+				return nil, err
 			}
 			eventMap[eventId.String] = event
 		}
@@ -84,54 +115,82 @@ func (r *mysqlEventRepository) ListEvents() ([]domain.Event, error) {
 			spot, spotExists := spotMap[spotId.String]
 			if !spotExists {
 				spot = &domain.Spot{
-					Id:       spotId.String,
-					EventId:  spotEventId.String,
-					Name:     spotName.String,
-					Status:   domain.SpotStatus(spotStatus.String),
-					TicketId: spotTicketId.String,
+					Id:         spotId.String,
+					EventId:    spotEventId.String,
+					Name:       spotName.String,
+					Zone:       spotZone.String,
+					Attributes: parseSpotAttributes(spotAttributes.String),
+					Status:     domain.SpotStatus(spotStatus.String),
+					TicketId:   spotTicketId.String,
+				}
+				if err := setSpotHold(spot, spotHoldId, spotHoldExpiresAt); err != nil {
+					return nil, err
 				}
 				event.Spots = append(event.Spots, *spot)
 				spotMap[spotId.String] = spot
 			}
 
 			if ticketId.Valid {
+				attendee, err := parseAttendee(ticketAttendeeName, ticketAttendeeBirthDate)
+				if err != nil {
+					return nil, err
+				}
 				ticket := domain.Ticket{
 					Id:         ticketId.String,
 					EventId:    ticketEventId.String,
 					Spot:       spot,
 					TicketType: domain.TicketType(ticketType.String),
 					Price:      ticketPrice.Float64,
+					Attendee:   attendee,
 				}
 				event.Tickets = append(event.Tickets, ticket)
 			}
 		}
 	}
 
-	events := make([]domain.Event, 0, len(eventMap))
-	for _, event := range eventMap {
-		events = append(events, *event)
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
+	phaseEvents := make([]*domain.Event, 0, len(eventMap))
+	for _, event := range eventMap {
+		phaseEvents = append(phaseEvents, event)
+	}
+	if err := loadSalesPhases(r.db, phaseEvents...); err != nil {
 		return nil, err
 	}
 
+	events := make([]domain.Event, 0, len(eventMap))
+	for _, event := range eventMap {
+		events = append(events, *event)
+	}
+
 	return events, nil
 }
 
 // FindEventById returns an event by its Id, including associated spots and tickets.
 func (r *mysqlEventRepository) FindEventById(eventId string) (*domain.Event, error) {
+	return r.findEvent("e.id = ?", eventId)
+}
+
+// FindOrganizationEvent returns an event of an organization by its Id,
+// including associated spots and tickets.
+func (r *mysqlEventRepository) FindOrganizationEvent(organizationId, eventId string) (*domain.Event, error) {
+	return r.findEvent("e.id = ? AND e.organization_id = ?", eventId, organizationId)
+}
+
+func (r *mysqlEventRepository) findEvent(condition string, args ...any) (*domain.Event, error) {
 	query := `
 		SELECT 
-			e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price, e.partner_id,
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
-			t.id, t.event_id, t.spot_id, t.ticket_type, t.price
+			e.id, e.name, e.location, e.venue_id, e.organization_id, e.organization, e.rating, e.date, e.timezone, e.image_url, e.capacity, e.price, e.partner_id, e.seating_mode, e.sold_tickets, e.status, e.parent_id, e.sales_start_at, e.sales_end_at, e.recurrence,
+			s.id, s.event_id, s.name, s.zone, s.attributes, s.status, s.ticket_id, s.hold_id, s.hold_expires_at,
+			t.id, t.event_id, t.spot_id, t.ticket_type, t.price, t.attendee_name, t.attendee_birth_date
 		FROM events e
 		LEFT JOIN spots s ON e.id = s.event_id
-		LEFT JOIN tickets t ON s.id = t.spot_id
-		WHERE e.id = ?
+		LEFT JOIN tickets t ON s.id = t.spot_id AND t.status <> 'cancelled'
+		WHERE ` + condition + `
 	`
-	rows, err := r.db.Query(query, eventId)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -139,16 +198,18 @@ func (r *mysqlEventRepository) FindEventById(eventId string) (*domain.Event, err
 
 	var event *domain.Event
 	for rows.Next() {
-		var eventIdStr, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotId, spotEventId, spotName, spotStatus, spotTicketId, ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
+		var eventIdStr, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotId, spotEventId, spotName, spotZone, spotAttributes, spotStatus, spotTicketId, spotHoldId, spotHoldExpiresAt, ticketId, ticketEventId, ticketSpotId, ticketType, ticketAttendeeName, ticketAttendeeBirthDate sql.NullString
 		var eventDate sql.NullString
-		var eventCapacity int
+		var eventCapacity, eventSoldTickets int
+		var eventVenueId, eventOrganizationId, eventSeatingMode, eventStatus, eventParentId, eventTimezone string
+		var eventSalesStartAt, eventSalesEndAt, eventRecurrence sql.NullString
 		var eventPrice, ticketPrice sql.NullFloat64
 		var partnerId sql.NullInt32
 
 		err := rows.Scan(
-			&eventIdStr, &eventName, &eventLocation, &eventOrganization, &eventRating, &eventDate, &eventImageURL, &eventCapacity, &eventPrice, &partnerId,
-			&spotId, &spotEventId, &spotName, &spotStatus, &spotTicketId,
-			&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice,
+			&eventIdStr, &eventName, &eventLocation, &eventVenueId, &eventOrganizationId, &eventOrganization, &eventRating, &eventDate, &eventTimezone, &eventImageURL, &eventCapacity, &eventPrice, &partnerId, &eventSeatingMode, &eventSoldTickets, &eventStatus, &eventParentId, &eventSalesStartAt, &eventSalesEndAt, &eventRecurrence,
+			&spotId, &spotEventId, &spotName, &spotZone, &spotAttributes, &spotStatus, &spotTicketId, &spotHoldId, &spotHoldExpiresAt,
+			&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice, &ticketAttendeeName, &ticketAttendeeBirthDate,
 		)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -162,43 +223,63 @@ func (r *mysqlEventRepository) FindEventById(eventId string) (*domain.Event, err
 		}
 
 		if event == nil {
-			eventDateParsed, err := time.Parse("2006-01-02 15:04:05", eventDate.String)
+			eventDateParsed, err := parseDateTime(eventDate.String)
 			if err != nil {
 				return nil, err
 			}
 			event = &domain.Event{
-				Id:           eventIdStr.String,
-				Name:         eventName.String,
-				Location:     eventLocation.String,
-				Organization: eventOrganization.String,
-				Rating:       domain.Rating(eventRating.String),
-				Date:         eventDateParsed,
-				ImageURL:     eventImageURL.String,
-				Capacity:     eventCapacity,
-				Price:        eventPrice.Float64,
-				PartnerId:    int(partnerId.Int32),
-				Spots:        []domain.Spot{},
-				Tickets:      []domain.Ticket{},
+				Id:             eventIdStr.String,
+				ParentId:       eventParentId,
+				Name:           eventName.String,
+				Location:       eventLocation.String,
+				VenueId:        eventVenueId,
+				OrganizationId: eventOrganizationId,
+				Organization:   eventOrganization.String,
+				Rating:         domain.Rating(eventRating.String),
+				Date:           eventDateParsed,
+				Timezone:       eventTimezone,
+				ImageURL:       eventImageURL.String,
+				Capacity:       eventCapacity,
+				Price:          eventPrice.Float64,
+				PartnerId:      int(partnerId.Int32),
+				SeatingMode:    domain.SeatingMode(eventSeatingMode),
+				SoldTickets:    eventSoldTickets,
+				Status:         domain.EventStatus(eventStatus),
+				Spots:          []domain.Spot{},
+				Tickets:        []domain.Ticket{},
+			}
+			if err := setEventSchedule(event, eventSalesStartAt, eventSalesEndAt, eventRecurrence); err != nil {
+				return nil, err
 			}
 		}
 
 		if spotId.Valid {
 			spot := domain.Spot{
-				Id:       spotId.String,
-				EventId:  spotEventId.String,
-				Name:     spotName.String,
-				Status:   domain.SpotStatus(spotStatus.String),
-				TicketId: spotTicketId.String,
+				Id:         spotId.String,
+				EventId:    spotEventId.String,
+				Name:       spotName.String,
+				Zone:       spotZone.String,
+				Attributes: parseSpotAttributes(spotAttributes.String),
+				Status:     domain.SpotStatus(spotStatus.String),
+				TicketId:   spotTicketId.String,
+			}
+			if err := setSpotHold(&spot, spotHoldId, spotHoldExpiresAt); err != nil {
+				return nil, err
 			}
 			event.Spots = append(event.Spots, spot)
 
 			if ticketId.Valid {
+				attendee, err := parseAttendee(ticketAttendeeName, ticketAttendeeBirthDate)
+				if err != nil {
+					return nil, err
+				}
 				ticket := domain.Ticket{
 					Id:         ticketId.String,
 					EventId:    ticketEventId.String,
 					Spot:       &spot,
 					TicketType: domain.TicketType(ticketType.String),
 					Price:      ticketPrice.Float64,
+					Attendee:   attendee,
 				}
 				event.Tickets = append(event.Tickets, ticket)
 			}
@@ -213,38 +294,119 @@ func (r *mysqlEventRepository) FindEventById(eventId string) (*domain.Event, err
 		return nil, domain.ErrEventNotFound
 	}
 
+	if err := loadSalesPhases(r.db, event); err != nil {
+		return nil, err
+	}
 	return event, nil
 }
 
-// CreateEvent inserts a new event into the database.
+// FindEventSessions returns the sessions of a recurring event by date.
+// Spots and tickets are not loaded.
+func (r *mysqlEventRepository) FindEventSessions(eventId string) ([]*domain.Event, error) {
+	rows, err := r.db.Query(`
+		SELECT id, parent_id, name, location, venue_id, organization_id, organization, rating, date, timezone, image_url, capacity, price, partner_id, seating_mode, sold_tickets, status, sales_start_at, sales_end_at
+		FROM events
+		WHERE parent_id = ?
+		ORDER BY date, id
+	`, eventId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Event
+	for rows.Next() {
+		session := domain.Event{Spots: []domain.Spot{}, Tickets: []domain.Ticket{}}
+		var date string
+		var salesStartAt, salesEndAt sql.NullString
+		err := rows.Scan(
+			&session.Id, &session.ParentId, &session.Name, &session.Location, &session.VenueId, &session.OrganizationId, &session.Organization, &session.Rating, &date, &session.Timezone,
+			&session.ImageURL, &session.Capacity, &session.Price, &session.PartnerId, &session.SeatingMode, &session.SoldTickets, &session.Status, &salesStartAt, &salesEndAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if session.Date, err = parseDateTime(date); err != nil {
+			return nil, err
+		}
+		if err := setEventSchedule(&session, salesStartAt, salesEndAt, sql.NullString{}); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadSalesPhases(r.db, sessions...); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// CreateEvent inserts a new event with its spots and sessions within a
+// single transaction.
 func (r *mysqlEventRepository) CreateEvent(event *domain.Event) error {
-	query := `
-		INSERT INTO events (id, name, location, organization, rating, date, image_url, capacity, price, partner_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := r.db.Exec(query, event.Id, event.Name, event.Location, event.Organization, event.Rating, event.Date.Format("2006-01-02 15:04:05"), event.ImageURL, event.Capacity, event.Price, event.PartnerId)
-	return err
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertEvent(tx, event); err != nil {
+		return err
+	}
+	for _, session := range event.Sessions {
+		if err := insertEvent(tx, session); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertEvent(db execer, event *domain.Event) error {
+	recurrence, err := formatRecurrence(event.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO events (id, parent_id, name, location, venue_id, organization_id, organization, rating, date, timezone, image_url, capacity, price, partner_id, seating_mode, sold_tickets, status, sales_start_at, sales_end_at, recurrence)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, event.Id, event.ParentId, event.Name, event.Location, event.VenueId, event.OrganizationId, event.Organization, event.Rating, event.Date.UTC().Format(dateTimeLayout), event.Timezone,
+		event.ImageURL, event.Capacity, event.Price, event.PartnerId, event.SeatingMode, event.SoldTickets, event.Status,
+		formatNullDateTime(event.SalesStartAt), formatNullDateTime(event.SalesEndAt), recurrence)
+	if err != nil {
+		return err
+	}
+
+	if err := insertSpots(db, event.Spots); err != nil {
+		return err
+	}
+	return insertDomainEvents(db, event.PullEvents())
 }
 
 // FindSpotById returns a spot by its Id, including the associated ticket (if any).
 func (r *mysqlEventRepository) FindSpotById(spotId string) (*domain.Spot, error) {
 	query := `
 		SELECT
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
+			s.id, s.event_id, s.name, s.zone, s.attributes, s.status, s.ticket_id, s.hold_id, s.hold_expires_at,
 			t.id, t.event_id, t.spot_id, t.ticket_type, t.price
 		FROM spots s
-		LEFT JOIN tickets t ON s.id = t.spot_id
+		LEFT JOIN tickets t ON s.id = t.spot_id AND t.status <> 'cancelled'
 		WHERE s.id = ?
 	`
 	row := r.db.QueryRow(query, spotId)
 
 	var spot domain.Spot
 	var ticket domain.Ticket
-	var ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
+	var attributes, holdId, holdExpiresAt, ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
 	var ticketPrice sql.NullFloat64
 
 	err := row.Scan(
-		&spot.Id, &spot.EventId, &spot.Name, &spot.Status, &spot.TicketId,
+		&spot.Id, &spot.EventId, &spot.Name, &spot.Zone, &attributes, &spot.Status, &spot.TicketId, &holdId, &holdExpiresAt,
 		&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice,
 	)
 	if err != nil {
@@ -254,6 +416,11 @@ func (r *mysqlEventRepository) FindSpotById(spotId string) (*domain.Spot, error)
 		return nil, err
 	}
 
+	spot.Attributes = parseSpotAttributes(attributes.String)
+	if err := setSpotHold(&spot, holdId, holdExpiresAt); err != nil {
+		return nil, err
+	}
+
 	if ticketId.Valid {
 		ticket.Id = ticketId.String
 		ticket.EventId = ticketEventId.String
@@ -269,38 +436,943 @@ func (r *mysqlEventRepository) FindSpotById(spotId string) (*domain.Spot, error)
 // CreateSpot inserts a new spot into the database.
 func (r *mysqlEventRepository) CreateSpot(spot *domain.Spot) error {
 	query := `
-		INSERT INTO spots (id, event_id, name, status, ticket_id)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO spots (id, event_id, name, zone, attributes, status, ticket_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.Exec(query, spot.Id, spot.EventId, spot.Name, spot.Status, spot.TicketId)
+	_, err := r.db.Exec(query, spot.Id, spot.EventId, spot.Name, spot.Zone, formatSpotAttributes(spot.Attributes), spot.Status, spot.TicketId)
 	return err
 }
 
-// CreateTicket inserts a new ticket into the database.
+// CreateSpots inserts spots in batches of spotsBatchSize rows, all within a
+// single transaction.
+func (r *mysqlEventRepository) CreateSpots(spots []domain.Spot) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertSpots(tx, spots); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertSpots inserts spots in batches of spotsBatchSize rows.
+func insertSpots(db execer, spots []domain.Spot) error {
+	for start := 0; start < len(spots); start += spotsBatchSize {
+		batch := spots[start:min(start+spotsBatchSize, len(spots))]
+
+		placeholders := make([]string, len(batch))
+		args := make([]any, 0, len(batch)*7)
+		for i, spot := range batch {
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, spot.Id, spot.EventId, spot.Name, spot.Zone, formatSpotAttributes(spot.Attributes), spot.Status, spot.TicketId)
+		}
+
+		query := "INSERT INTO spots (id, event_id, name, zone, attributes, status, ticket_id) VALUES " + strings.Join(placeholders, ", ")
+		if _, err := db.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTicket inserts a new ticket into the database, recording its buyer
+// as the first entry of the holder history.
 func (r *mysqlEventRepository) CreateTicket(ticket *domain.Ticket) error {
+	var spotId, attendeeBirthDate sql.NullString
+	if ticket.Spot != nil {
+		spotId = sql.NullString{String: ticket.Spot.Id, Valid: true}
+	}
+	if !ticket.Attendee.BirthDate.IsZero() {
+		attendeeBirthDate = sql.NullString{String: ticket.Attendee.BirthDate.Format(dateLayout), Valid: true}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO tickets (id, event_id, order_id, spot_id, ticket_type, status, price, attendee_name, attendee_birth_date, holder_email, customer_id, sales_phase)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, ticket.Id, ticket.EventId, ticket.OrderId, spotId, ticket.TicketType, ticket.Status, ticket.Price, ticket.Attendee.Name, attendeeBirthDate, ticket.HolderEmail, ticket.CustomerId, ticket.SalesPhase)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO ticket_holders (ticket_id, email, transfer_id, since)
+		VALUES (?, ?, '', UTC_TIMESTAMP())
+	`, ticket.Id, ticket.HolderEmail)
+	if err != nil {
+		return err
+	}
+
+	if err := insertDomainEvents(tx, ticket.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReserveSpot stores a spot as sold to its ticket, clearing any hold. A spot
+// that is already sold is never overwritten, and neither is one under an
+// active hold other than holdId, so a concurrent checkout cannot take a
+// spot held by someone else.
+func (r *mysqlEventRepository) ReserveSpot(spot *domain.Spot, holdId string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE spots
+		SET status = ?, ticket_id = ?, hold_id = NULL, hold_expires_at = NULL
+		WHERE id = ? AND status <> ?
+			AND (hold_id IS NULL OR hold_id = ? OR hold_expires_at <= UTC_TIMESTAMP())
+	`, domain.SpotStatusSold, spot.TicketId, spot.Id, domain.SpotStatusSold, holdId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var status domain.SpotStatus
+		if err := tx.QueryRow(`SELECT status FROM spots WHERE id = ?`, spot.Id).Scan(&status); err != nil {
+			return err
+		}
+		if status == domain.SpotStatusSold {
+			return domain.ErrSpotAlreadyReserved
+		}
+		return domain.ErrSpotHeld
+	}
+
+	if err := insertDomainEvents(tx, spot.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ClaimTickets atomically adds quantity to the event's sold tickets, failing
+// with domain.ErrEventSoldOut when that would exceed its capacity.
+func (r *mysqlEventRepository) ClaimTickets(eventId string, quantity int) error {
+	query := `
+		UPDATE events
+		SET sold_tickets = sold_tickets + ?
+		WHERE id = ? AND sold_tickets + ? <= capacity
+	`
+	result, err := r.db.Exec(query, quantity, eventId, quantity)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrEventSoldOut
+	}
+	return nil
+}
+
+// ReleaseTickets gives quantity tickets back to the event's capacity.
+func (r *mysqlEventRepository) ReleaseTickets(eventId string, quantity int) error {
 	query := `
-		INSERT INTO tickets (id, event_id, spot_id, ticket_type, price)
-		VALUES (?, ?, ?, ?, ?)
+		UPDATE events
+		SET sold_tickets = GREATEST(sold_tickets - ?, 0)
+		WHERE id = ?
 	`
-	_, err := r.db.Exec(query, ticket.Id, ticket.EventId, ticket.Spot.Id, ticket.TicketType, ticket.Price)
+	_, err := r.db.Exec(query, quantity, eventId)
 	return err
 }
 
-// ReserveSpot updates a spot's status to reserved.
-func (r *mysqlEventRepository) ReserveSpot(spotId, ticketId string) error {
+// CreateHold inserts a hold and marks its spots as held. The spots are only
+// updated while still available (or held under an expired hold), so two
+// concurrent holds on the same spot can never both succeed. General
+// admission holds claim their quantity from the event capacity instead.
+func (r *mysqlEventRepository) CreateHold(hold *domain.Hold) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	expiresAt := hold.ExpiresAt.UTC().Format(dateTimeLayout)
+	_, err = tx.Exec(`
+		INSERT INTO holds (id, event_id, ticket_type, email, quantity, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, hold.Id, hold.EventId, hold.TicketType, hold.Email, hold.Quantity, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if hold.Quantity > 0 {
+		result, err := tx.Exec(`
+			UPDATE events
+			SET sold_tickets = sold_tickets + ?
+			WHERE id = ? AND sold_tickets + ? <= capacity
+		`, hold.Quantity, hold.EventId, hold.Quantity)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrEventSoldOut
+		}
+	}
+
+	for _, spot := range hold.Spots {
+		result, err := tx.Exec(`
+			UPDATE spots
+			SET status = ?, hold_id = ?, hold_expires_at = ?
+			WHERE id = ? AND (status = ? OR (status = ? AND hold_expires_at <= UTC_TIMESTAMP()))
+		`, domain.SpotStatusHeld, hold.Id, expiresAt, spot.Id, domain.SpotStatusAvailable, domain.SpotStatusHeld)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrSpotHeld
+		}
+
+		if err := insertDomainEvents(tx, spot.PullEvents()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindHoldById returns a hold by its Id together with its spots.
+func (r *mysqlEventRepository) FindHoldById(holdId string) (*domain.Hold, error) {
+	var hold domain.Hold
+	var ticketType, expiresAt string
+	err := r.db.QueryRow(`
+		SELECT id, event_id, ticket_type, email, quantity, expires_at
+		FROM holds
+		WHERE id = ?
+	`, holdId).Scan(&hold.Id, &hold.EventId, &ticketType, &hold.Email, &hold.Quantity, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrHoldNotFound
+		}
+		return nil, err
+	}
+
+	hold.TicketType = domain.TicketType(ticketType)
+	hold.ExpiresAt, err = parseDateTime(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	spots, err := r.FindSpotsByEventId(hold.EventId)
+	if err != nil {
+		return nil, err
+	}
+	for _, spot := range spots {
+		if spot.HoldId == hold.Id {
+			hold.Spots = append(hold.Spots, spot)
+		}
+	}
+
+	return &hold, nil
+}
+
+// ClaimHoldTickets moves quantity tickets of a general admission hold to a
+// purchase. The capacity was claimed when the hold was created, so the
+// tickets are only taken from the hold while it has not expired.
+func (r *mysqlEventRepository) ClaimHoldTickets(holdId string, quantity int) error {
 	query := `
-		UPDATE spots
-		SET status = ?, ticket_id = ?
+		UPDATE holds
+		SET quantity = quantity - ?
+		WHERE id = ? AND quantity >= ? AND expires_at > UTC_TIMESTAMP()
+	`
+	result, err := r.db.Exec(query, quantity, holdId, quantity)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrHoldExpired
+	}
+	return nil
+}
+
+// ReleaseHoldTickets gives quantity tickets back to a general admission
+// hold after its purchase failed.
+func (r *mysqlEventRepository) ReleaseHoldTickets(holdId string, quantity int) error {
+	query := `
+		UPDATE holds
+		SET quantity = quantity + ?
 		WHERE id = ?
 	`
-	_, err := r.db.Exec(query, domain.SpotStatusSold, ticketId, spotId)
+	_, err := r.db.Exec(query, quantity, holdId)
+	return err
+}
+
+// FindPurchaseLimits returns the purchase limits of an event. Events without
+// configured limits get a zero value, which allows any purchase.
+func (r *mysqlEventRepository) FindPurchaseLimits(eventId string) (*domain.PurchaseLimits, error) {
+	query := `
+		SELECT max_per_order, max_per_email, max_per_card, max_per_window, window_seconds
+		FROM event_purchase_limits
+		WHERE event_id = ?
+	`
+	limits := domain.PurchaseLimits{EventId: eventId}
+	var windowSeconds int64
+	err := r.db.QueryRow(query, eventId).Scan(&limits.MaxPerOrder, &limits.MaxPerEmail, &limits.MaxPerCard, &limits.MaxPerWindow, &windowSeconds)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &limits, nil
+		}
+		return nil, err
+	}
+
+	limits.Window = time.Duration(windowSeconds) * time.Second
+	return &limits, nil
+}
+
+// SavePurchaseLimits creates or replaces the purchase limits of an event.
+func (r *mysqlEventRepository) SavePurchaseLimits(limits *domain.PurchaseLimits) error {
+	query := `
+		INSERT INTO event_purchase_limits (event_id, max_per_order, max_per_email, max_per_card, max_per_window, window_seconds)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			max_per_order = VALUES(max_per_order),
+			max_per_email = VALUES(max_per_email),
+			max_per_card = VALUES(max_per_card),
+			max_per_window = VALUES(max_per_window),
+			window_seconds = VALUES(window_seconds)
+	`
+	_, err := r.db.Exec(query, limits.EventId, limits.MaxPerOrder, limits.MaxPerEmail, limits.MaxPerCard, limits.MaxPerWindow, int64(limits.Window/time.Second))
+	return err
+}
+
+// SaveSalesPhases replaces the sales phases of an event within a single
+// transaction. The tickets sold in a phase are left alone, as only
+// ClaimSalesPhaseTickets and ReleaseSalesPhaseTickets change them.
+func (r *mysqlEventRepository) SaveSalesPhases(event *domain.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	kinds := []any{event.Id}
+	placeholders := make([]string, 0, len(event.SalesPhases))
+	for _, phase := range event.SalesPhases {
+		kinds = append(kinds, phase.Kind)
+		placeholders = append(placeholders, "?")
+	}
+	query := `DELETE FROM event_sales_phases WHERE event_id = ?`
+	if len(placeholders) > 0 {
+		query += ` AND kind NOT IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	if _, err := tx.Exec(query, kinds...); err != nil {
+		return err
+	}
+
+	for _, phase := range event.SalesPhases {
+		_, err := tx.Exec(`
+			INSERT INTO event_sales_phases (event_id, kind, starts_at, ends_at, price, quota, access_code_hash)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				starts_at = VALUES(starts_at),
+				ends_at = VALUES(ends_at),
+				price = VALUES(price),
+				quota = VALUES(quota),
+				access_code_hash = VALUES(access_code_hash)
+		`, event.Id, phase.Kind, phase.StartsAt.UTC().Format(dateTimeLayout), phase.EndsAt.UTC().Format(dateTimeLayout), phase.Price, phase.Quota, phase.AccessCodeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClaimSalesPhaseTickets atomically adds quantity to the tickets sold in a
+// sales phase, failing with domain.ErrSalesPhaseSoldOut when that would
+// exceed its quota.
+func (r *mysqlEventRepository) ClaimSalesPhaseTickets(eventId string, kind domain.SalesPhaseKind, quantity int) error {
+	query := `
+		UPDATE event_sales_phases
+		SET sold_tickets = sold_tickets + ?
+		WHERE event_id = ? AND kind = ? AND (quota = 0 OR sold_tickets + ? <= quota)
+	`
+	result, err := r.db.Exec(query, quantity, eventId, kind, quantity)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrSalesPhaseSoldOut
+	}
+	return nil
+}
+
+// ReleaseSalesPhaseTickets gives quantity tickets back to the quota of a
+// sales phase.
+func (r *mysqlEventRepository) ReleaseSalesPhaseTickets(eventId string, kind domain.SalesPhaseKind, quantity int) error {
+	query := `
+		UPDATE event_sales_phases
+		SET sold_tickets = GREATEST(sold_tickets - ?, 0)
+		WHERE event_id = ? AND kind = ?
+	`
+	_, err := r.db.Exec(query, quantity, eventId, kind)
 	return err
 }
 
+// loadSalesPhases fills the sales phases of events.
+func loadSalesPhases(db *sql.DB, events ...*domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventMap := make(map[string]*domain.Event, len(events))
+	ids := make([]any, 0, len(events))
+	placeholders := make([]string, 0, len(events))
+	for _, event := range events {
+		eventMap[event.Id] = event
+		ids = append(ids, event.Id)
+		placeholders = append(placeholders, "?")
+	}
+
+	rows, err := db.Query(`
+		SELECT event_id, kind, starts_at, ends_at, price, quota, sold_tickets, access_code_hash
+		FROM event_sales_phases
+		WHERE event_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY starts_at
+	`, ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var phase domain.SalesPhase
+		var startsAt, endsAt string
+		err := rows.Scan(&phase.EventId, &phase.Kind, &startsAt, &endsAt, &phase.Price, &phase.Quota, &phase.SoldTickets, &phase.AccessCodeHash)
+		if err != nil {
+			return err
+		}
+		if phase.StartsAt, err = parseDateTime(startsAt); err != nil {
+			return err
+		}
+		if phase.EndsAt, err = parseDateTime(endsAt); err != nil {
+			return err
+		}
+		event := eventMap[phase.EventId]
+		event.SalesPhases = append(event.SalesPhases, phase)
+	}
+	return rows.Err()
+}
+
+// FindExpiredHolds returns the holds expired at the given time that still
+// have spots or general admission tickets held, without loading their
+// spots.
+func (r *mysqlEventRepository) FindExpiredHolds(at time.Time) ([]*domain.Hold, error) {
+	query := `
+		SELECT h.id, h.event_id, h.ticket_type, h.email, h.quantity, h.expires_at
+		FROM holds h
+		WHERE h.expires_at <= ? AND (h.quantity > 0 OR EXISTS (
+			SELECT 1 FROM spots s WHERE s.hold_id = h.id AND s.status = ?
+		))
+	`
+	rows, err := r.db.Query(query, at.UTC().Format(dateTimeLayout), domain.SpotStatusHeld)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []*domain.Hold
+	for rows.Next() {
+		var hold domain.Hold
+		var ticketType, expiresAt string
+		if err := rows.Scan(&hold.Id, &hold.EventId, &ticketType, &hold.Email, &hold.Quantity, &expiresAt); err != nil {
+			return nil, err
+		}
+		hold.TicketType = domain.TicketType(ticketType)
+		hold.ExpiresAt, err = parseDateTime(expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, &hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// ReleaseHold makes the spots still held by a hold available again and
+// gives the general admission tickets it still keeps back to the event
+// capacity.
+func (r *mysqlEventRepository) ReleaseHold(holdId string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE spots
+		SET status = ?, hold_id = NULL, hold_expires_at = NULL
+		WHERE hold_id = ? AND status = ?
+	`, domain.SpotStatusAvailable, holdId, domain.SpotStatusHeld)
+	if err != nil {
+		return err
+	}
+
+	// Bloqueando o hold para não devolver os mesmos ingressos duas vezes.
+	var eventId string
+	var quantity int
+	err = tx.QueryRow(`SELECT event_id, quantity FROM holds WHERE id = ? FOR UPDATE`, holdId).Scan(&eventId, &quantity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tx.Commit()
+		}
+		return err
+	}
+	if quantity > 0 {
+		if _, err := tx.Exec(`UPDATE holds SET quantity = 0 WHERE id = ?`, holdId); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			UPDATE events
+			SET sold_tickets = GREATEST(sold_tickets - ?, 0)
+			WHERE id = ?
+		`, quantity, eventId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindTicketById returns a ticket by its Id, including its spot (if any).
+func (r *mysqlEventRepository) FindTicketById(ticketId string) (*domain.Ticket, error) {
+	return r.findTicket("t.id = ?", ticketId)
+}
+
+// FindOrganizationTicket returns a ticket of an event of an organization
+// by its Id, including its spot (if any).
+func (r *mysqlEventRepository) FindOrganizationTicket(organizationId, ticketId string) (*domain.Ticket, error) {
+	return r.findTicket("t.id = ? AND e.organization_id = ?", ticketId, organizationId)
+}
+
+func (r *mysqlEventRepository) findTicket(condition string, args ...any) (*domain.Ticket, error) {
+	query := `
+		SELECT t.id, t.event_id, t.order_id, t.spot_id, t.ticket_type, t.status, t.price, t.attendee_name, t.attendee_birth_date, t.holder_email, t.customer_id, t.sales_phase, t.used_at, t.gate_id, t.credential_version
+		FROM tickets t
+		JOIN events e ON e.id = t.event_id
+		WHERE ` + condition + `
+	`
+	var ticket domain.Ticket
+	var spotId, attendeeName, attendeeBirthDate, usedAt sql.NullString
+	err := r.db.QueryRow(query, args...).Scan(
+		&ticket.Id, &ticket.EventId, &ticket.OrderId, &spotId, &ticket.TicketType, &ticket.Status, &ticket.Price, &attendeeName, &attendeeBirthDate, &ticket.HolderEmail, &ticket.CustomerId, &ticket.SalesPhase, &usedAt, &ticket.GateId, &ticket.CredentialVersion,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTicketNotFound
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		ticket.UsedAt, err = parseDateTime(usedAt.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ticket.Attendee, err = parseAttendee(attendeeName, attendeeBirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if spotId.Valid {
+		ticket.Spot, err = r.FindSpotById(spotId.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ticket, nil
+}
+
+// CancelTicket marks a ticket as cancelled, frees its spot and gives its
+// place back to the event capacity, all within a single transaction.
+func (r *mysqlEventRepository) CancelTicket(ticket *domain.Ticket) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE tickets
+		SET status = ?
+		WHERE id = ? AND status = ?
+	`, domain.TicketStatusCancelled, ticket.Id, domain.TicketStatusActive)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTicketAlreadyCancelled
+	}
+
+	if ticket.Spot != nil {
+		_, err = tx.Exec(`
+			UPDATE spots
+			SET status = ?, ticket_id = ''
+			WHERE id = ? AND ticket_id = ?
+		`, domain.SpotStatusAvailable, ticket.Spot.Id, ticket.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE events
+		SET sold_tickets = GREATEST(sold_tickets - 1, 0)
+		WHERE id = ?
+	`, ticket.EventId)
+	if err != nil {
+		return err
+	}
+
+	if ticket.SalesPhase != "" {
+		_, err = tx.Exec(`
+			UPDATE event_sales_phases
+			SET sold_tickets = GREATEST(sold_tickets - 1, 0)
+			WHERE event_id = ? AND kind = ?
+		`, ticket.EventId, ticket.SalesPhase)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := insertDomainEvents(tx, ticket.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CancelEvent marks the event as cancelled and cancels all its active
+// tickets, freeing their spots, within a single transaction.
+func (r *mysqlEventRepository) CancelEvent(event *domain.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE events
+		SET status = ?, sold_tickets = 0
+		WHERE id = ? AND status <> ?
+	`, domain.EventStatusCancelled, event.Id, domain.EventStatusCancelled)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrEventCancelled
+	}
+
+	_, err = tx.Exec(`
+		UPDATE tickets
+		SET status = ?
+		WHERE event_id = ? AND status = ?
+	`, domain.TicketStatusCancelled, event.Id, domain.TicketStatusActive)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE spots
+		SET status = ?, ticket_id = '', hold_id = NULL, hold_expires_at = NULL
+		WHERE event_id = ?
+	`, domain.SpotStatusAvailable, event.Id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE event_sales_phases
+		SET sold_tickets = 0
+		WHERE event_id = ?
+	`, event.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := insertDomainEvents(tx, event.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindActiveTicketsByEventId returns the tickets of an event that can still
+// be used, with their spots.
+func (r *mysqlEventRepository) FindActiveTicketsByEventId(eventId string) ([]domain.Ticket, error) {
+	rows, err := r.db.Query(`
+		SELECT t.id, t.order_id, t.ticket_type, t.price, t.attendee_name, t.attendee_birth_date, t.holder_email,
+			s.id, s.name, s.zone
+		FROM tickets t
+		LEFT JOIN spots s ON s.id = t.spot_id
+		WHERE t.event_id = ? AND t.status = ?
+		ORDER BY t.holder_email, s.name, t.id
+	`, eventId, domain.TicketStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []domain.Ticket
+	for rows.Next() {
+		ticket := domain.Ticket{EventId: eventId, Status: domain.TicketStatusActive}
+		var attendeeName, attendeeBirthDate, spotId, spotName, spotZone sql.NullString
+		err := rows.Scan(
+			&ticket.Id, &ticket.OrderId, &ticket.TicketType, &ticket.Price, &attendeeName, &attendeeBirthDate, &ticket.HolderEmail,
+			&spotId, &spotName, &spotZone,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ticket.Attendee, err = parseAttendee(attendeeName, attendeeBirthDate); err != nil {
+			return nil, err
+		}
+		if spotId.Valid {
+			ticket.Spot = &domain.Spot{Id: spotId.String, EventId: eventId, Name: spotName.String, Zone: spotZone.String}
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, rows.Err()
+}
+
+// FindEventsToRemind returns the scheduled events starting between from and
+// to whose reminders have not been sent yet. Spots and tickets are not
+// loaded.
+func (r *mysqlEventRepository) FindEventsToRemind(from, to time.Time) ([]*domain.Event, error) {
+	rows, err := r.db.Query(`
+		SELECT id
+		FROM events
+		WHERE status = ? AND reminded_at IS NULL AND date >= ? AND date < ?
+		ORDER BY date
+	`, domain.EventStatusScheduled, from.UTC().Format(dateTimeLayout), to.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	var eventIds []string
+	for rows.Next() {
+		var eventId string
+		if err := rows.Scan(&eventId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		eventIds = append(eventIds, eventId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.Event, 0, len(eventIds))
+	for _, eventId := range eventIds {
+		event, err := r.FindEventById(eventId)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// MarkEventReminded records that the reminders of an event were enqueued.
+func (r *mysqlEventRepository) MarkEventReminded(eventId string, at time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE events
+		SET reminded_at = ?
+		WHERE id = ?
+	`, at.UTC().Format(dateTimeLayout), eventId)
+	return err
+}
+
+// CheckInTicket marks an active ticket as used. The status guard makes two
+// gates scanning the same ticket at once let only one of them through.
+func (r *mysqlEventRepository) CheckInTicket(ticket *domain.Ticket) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE tickets
+		SET status = ?, used_at = ?, gate_id = ?
+		WHERE id = ? AND status = ?
+	`, domain.TicketStatusUsed, ticket.UsedAt.UTC().Format(dateTimeLayout), ticket.GateId, ticket.Id, domain.TicketStatusActive)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTicketAlreadyUsed
+	}
+
+	if err := insertDomainEvents(tx, ticket.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindCheckInTickets returns the event tickets that have not entered yet
+// with their credential version.
+func (r *mysqlEventRepository) FindCheckInTickets(eventId string) ([]domain.CheckInManifestTicket, error) {
+	rows, err := r.db.Query(`
+		SELECT id, credential_version
+		FROM tickets
+		WHERE event_id = ? AND status = ?
+		ORDER BY id
+	`, eventId, domain.TicketStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tickets := []domain.CheckInManifestTicket{}
+	for rows.Next() {
+		var ticket domain.CheckInManifestTicket
+		if err := rows.Scan(&ticket.TicketId, &ticket.CredentialVersion); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, rows.Err()
+}
+
+// setSpotHold fills the hold fields of spot from their nullable columns.
+func setSpotHold(spot *domain.Spot, holdId, holdExpiresAt sql.NullString) error {
+	spot.HoldId = holdId.String
+	if !holdExpiresAt.Valid {
+		return nil
+	}
+
+	expiresAt, err := parseDateTime(holdExpiresAt.String)
+	if err != nil {
+		return err
+	}
+	spot.HoldExpiresAt = expiresAt
+	return nil
+}
+
+// recurrenceRecord is how a recurrence rule is stored in events.recurrence.
+type recurrenceRecord struct {
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval"`
+	Weekdays  []int  `json:"weekdays,omitempty"`
+	Until     string `json:"until,omitempty"`
+	Count     int    `json:"count,omitempty"`
+}
+
+// formatRecurrence stores events without a recurrence as NULL.
+func formatRecurrence(rule *domain.RecurrenceRule) (sql.NullString, error) {
+	if rule == nil {
+		return sql.NullString{}, nil
+	}
+	record := recurrenceRecord{Frequency: string(rule.Frequency), Interval: rule.Interval, Count: rule.Count}
+	for _, weekday := range rule.Weekdays {
+		record.Weekdays = append(record.Weekdays, int(weekday))
+	}
+	if !rule.Until.IsZero() {
+		record.Until = rule.Until.UTC().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// setEventSchedule fills the sales window and recurrence of event from their
+// nullable columns.
+func setEventSchedule(event *domain.Event, salesStartAt, salesEndAt, recurrence sql.NullString) error {
+	var err error
+	if salesStartAt.Valid {
+		if event.SalesStartAt, err = parseDateTime(salesStartAt.String); err != nil {
+			return err
+		}
+	}
+	if salesEndAt.Valid {
+		if event.SalesEndAt, err = parseDateTime(salesEndAt.String); err != nil {
+			return err
+		}
+	}
+	if !recurrence.Valid {
+		return nil
+	}
+
+	var record recurrenceRecord
+	if err := json.Unmarshal([]byte(recurrence.String), &record); err != nil {
+		return err
+	}
+	rule := &domain.RecurrenceRule{Frequency: domain.RecurrenceFrequency(record.Frequency), Interval: record.Interval, Count: record.Count}
+	for _, weekday := range record.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(weekday))
+	}
+	if record.Until != "" {
+		if rule.Until, err = time.Parse(time.RFC3339, record.Until); err != nil {
+			return err
+		}
+	}
+	event.Recurrence = rule
+	return nil
+}
+
 // FindSpotsByEventId returns all spots for a given event Id.
 func (r *mysqlEventRepository) FindSpotsByEventId(eventId string) ([]*domain.Spot, error) {
 	query := `
-		SELECT id, event_id, name, status, ticket_id
+		SELECT id, event_id, name, zone, attributes, status, ticket_id, hold_id, hold_expires_at
 		FROM spots
 		WHERE event_id = ?
 	`
@@ -313,7 +1385,12 @@ func (r *mysqlEventRepository) FindSpotsByEventId(eventId string) ([]*domain.Spo
 	var spots []*domain.Spot
 	for rows.Next() {
 		var spot domain.Spot
-		if err := rows.Scan(&spot.Id, &spot.EventId, &spot.Name, &spot.Status, &spot.TicketId); err != nil {
+		var attributes, holdId, holdExpiresAt sql.NullString
+		if err := rows.Scan(&spot.Id, &spot.EventId, &spot.Name, &spot.Zone, &attributes, &spot.Status, &spot.TicketId, &holdId, &holdExpiresAt); err != nil {
+			return nil, err
+		}
+		spot.Attributes = parseSpotAttributes(attributes.String)
+		if err := setSpotHold(&spot, holdId, holdExpiresAt); err != nil {
 			return nil, err
 		}
 		spots = append(spots, &spot)
@@ -329,21 +1406,21 @@ func (r *mysqlEventRepository) FindSpotsByEventId(eventId string) ([]*domain.Spo
 func (r *mysqlEventRepository) FindSpotByName(eventId, name string) (*domain.Spot, error) {
 	query := `
 		SELECT 
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
+			s.id, s.event_id, s.name, s.zone, s.attributes, s.status, s.ticket_id, s.hold_id, s.hold_expires_at,
 			t.id, t.event_id, t.spot_id, t.ticket_type, t.price
 		FROM spots s
-		LEFT JOIN tickets t ON s.id = t.spot_id
+		LEFT JOIN tickets t ON s.id = t.spot_id AND t.status <> 'cancelled'
 		WHERE s.event_id = ? AND s.name = ?
 	`
 	row := r.db.QueryRow(query, eventId, name)
 
 	var spot domain.Spot
 	var ticket domain.Ticket
-	var ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
+	var attributes, holdId, holdExpiresAt, ticketId, ticketEventId, ticketSpotId, ticketType sql.NullString
 	var ticketPrice sql.NullFloat64
 
 	err := row.Scan(
-		&spot.Id, &spot.EventId, &spot.Name, &spot.Status, &spot.TicketId,
+		&spot.Id, &spot.EventId, &spot.Name, &spot.Zone, &attributes, &spot.Status, &spot.TicketId, &holdId, &holdExpiresAt,
 		&ticketId, &ticketEventId, &ticketSpotId, &ticketType, &ticketPrice,
 	)
 	if err != nil {
@@ -353,6 +1430,11 @@ func (r *mysqlEventRepository) FindSpotByName(eventId, name string) (*domain.Spo
 		return nil, err
 	}
 
+	spot.Attributes = parseSpotAttributes(attributes.String)
+	if err := setSpotHold(&spot, holdId, holdExpiresAt); err != nil {
+		return nil, err
+	}
+
 	if ticketId.Valid {
 		ticket.Id = ticketId.String
 		ticket.EventId = ticketEventId.String
@@ -364,3 +1446,40 @@ func (r *mysqlEventRepository) FindSpotByName(eventId, name string) (*domain.Spo
 
 	return &spot, nil
 }
+
+// parseAttendee builds a ticket attendee from its nullable columns.
+func parseAttendee(name, birthDate sql.NullString) (domain.Attendee, error) {
+	attendee := domain.Attendee{Name: name.String}
+	if !birthDate.Valid {
+		return attendee, nil
+	}
+
+	parsed, err := time.Parse(dateLayout, birthDate.String)
+	if err != nil {
+		return attendee, err
+	}
+	attendee.BirthDate = parsed
+	return attendee, nil
+}
+
+// parseSpotAttributes decodes the comma separated attributes column.
+func parseSpotAttributes(value string) []domain.SpotAttribute {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	attributes := make([]domain.SpotAttribute, len(parts))
+	for i, part := range parts {
+		attributes[i] = domain.SpotAttribute(part)
+	}
+	return attributes
+}
+
+// formatSpotAttributes is the inverse of parseSpotAttributes.
+func formatSpotAttributes(attributes []domain.SpotAttribute) string {
+	parts := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		parts[i] = string(attribute)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlNotificationRepository creates a new MySQL notification outbox.
+func NewMysqlNotificationRepository(db *sql.DB) (domain.NotificationRepository, error) {
+	return &mysqlNotificationRepository{db: db}, nil
+}
+
+const notificationColumns = `id, kind, recipient, subject, body, dedup_key, status, attempts, last_error, created_at, next_attempt_at, sent_at`
+
+// CreateNotification adds a notification to the outbox. A notification
+// whose dedup key is already there is silently ignored.
+func (r *mysqlNotificationRepository) CreateNotification(notification *domain.Notification) error {
+	query := `
+		INSERT IGNORE INTO notifications (` + notificationColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(query,
+		notification.Id, notification.Kind, notification.Recipient, notification.Subject, notification.Body,
+		notification.DedupKey, notification.Status, notification.Attempts, notification.LastError,
+		notification.CreatedAt.UTC().Format(dateTimeLayout), notification.NextAttemptAt.UTC().Format(dateTimeLayout),
+		formatNullDateTime(notification.SentAt),
+	)
+	return err
+}
+
+// FindDueNotifications returns up to limit pending notifications whose next
+// attempt is due, oldest first.
+func (r *mysqlNotificationRepository) FindDueNotifications(at time.Time, limit int) ([]*domain.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, created_at
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, domain.NotificationStatusPending, at.UTC().Format(dateTimeLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+// UpdateNotification stores the delivery state of a notification.
+func (r *mysqlNotificationRepository) UpdateNotification(notification *domain.Notification) error {
+	query := `
+		UPDATE notifications
+		SET status = ?, attempts = ?, last_error = ?, next_attempt_at = ?, sent_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query,
+		notification.Status, notification.Attempts, notification.LastError,
+		notification.NextAttemptAt.UTC().Format(dateTimeLayout), formatNullDateTime(notification.SentAt),
+		notification.Id,
+	)
+	return err
+}
+
+func scanNotification(row rowScanner) (*domain.Notification, error) {
+	var notification domain.Notification
+	var createdAt, nextAttemptAt string
+	var sentAt sql.NullString
+	err := row.Scan(
+		&notification.Id, &notification.Kind, &notification.Recipient, &notification.Subject, &notification.Body,
+		&notification.DedupKey, &notification.Status, &notification.Attempts, &notification.LastError,
+		&createdAt, &nextAttemptAt, &sentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if notification.NextAttemptAt, err = parseDateTime(nextAttemptAt); err != nil {
+		return nil, err
+	}
+	if sentAt.Valid {
+		if notification.SentAt, err = parseDateTime(sentAt.String); err != nil {
+			return nil, err
+		}
+	}
+	return &notification, nil
+}
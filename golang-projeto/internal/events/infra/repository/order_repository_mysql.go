@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlOrderRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlOrderRepository creates a new MySQL order repository.
+func NewMysqlOrderRepository(db *sql.DB) (domain.OrderRepository, error) {
+	return &mysqlOrderRepository{db: db}, nil
+}
+
+// CreateOrder inserts a new order into the database, replacing its
+// purchase claim. Its tickets are stored separately through
+// EventRepository.CreateTicket.
+func (r *mysqlOrderRepository) CreateOrder(order *domain.Order) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO orders (id, event_id, hold_id, user_id, customer_id, email, card_hash, total, created_at, payment_id, payment_status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.Exec(query, order.Id, order.EventId, order.HoldId, order.UserId, order.CustomerId, order.Email, order.CardHash, order.Total, order.CreatedAt.UTC().Format(dateTimeLayout), order.PaymentId, order.PaymentStatus)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM purchase_claims WHERE order_id = ?`, order.Id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateOrderPayment stores the payment state of an order.
+func (r *mysqlOrderRepository) UpdateOrderPayment(order *domain.Order) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE orders
+		SET payment_id = ?, payment_status = ?
+		WHERE id = ?
+	`, order.PaymentId, order.PaymentStatus, order.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := insertDomainEvents(tx, order.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindOrderById returns an order with all its tickets, cancelled ones
+// included.
+func (r *mysqlOrderRepository) FindOrderById(orderId string) (*domain.Order, error) {
+	query := `
+		SELECT id, event_id, hold_id, user_id, customer_id, email, card_hash, total, created_at, payment_id, payment_status
+		FROM orders
+		WHERE id = ?
+	`
+	var order domain.Order
+	var createdAt string
+	err := r.db.QueryRow(query, orderId).Scan(&order.Id, &order.EventId, &order.HoldId, &order.UserId, &order.CustomerId, &order.Email, &order.CardHash, &order.Total, &createdAt, &order.PaymentId, &order.PaymentStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if order.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	if order.Tickets, err = findOrderTickets(r.db, order.Id); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// HasPaidHoldOrder tells whether the customer with email placed an order
+// with the hold and paid for it, even if it was refunded later.
+func (r *mysqlOrderRepository) HasPaidHoldOrder(holdId, email string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM orders
+			WHERE hold_id = ? AND email = ? AND payment_status IN (?, ?)
+		)
+	`
+	var paid bool
+	err := r.db.QueryRow(query, holdId, domain.NormalizeEmail(email), domain.PaymentStatusCaptured, domain.PaymentStatusRefunded).Scan(&paid)
+	return paid, err
+}
+
+// orderTicketColumns are read by scanOrderTicket, from tickets t joined
+// with their spots s.
+const orderTicketColumns = `t.id, t.event_id, t.order_id, t.ticket_type, t.status, t.price, t.attendee_name, t.attendee_birth_date, t.holder_email, t.customer_id,
+	s.id, s.name, s.zone`
+
+// findOrderTickets returns the tickets of an order, cancelled ones
+// included.
+func findOrderTickets(db *sql.DB, orderId string) ([]domain.Ticket, error) {
+	rows, err := db.Query(`
+		SELECT `+orderTicketColumns+`
+		FROM tickets t
+		LEFT JOIN spots s ON s.id = t.spot_id
+		WHERE t.order_id = ?
+		ORDER BY s.name, t.id
+	`, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []domain.Ticket
+	for rows.Next() {
+		ticket, err := scanOrderTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, *ticket)
+	}
+	return tickets, rows.Err()
+}
+
+func scanOrderTicket(row rowScanner, extra ...any) (*domain.Ticket, error) {
+	var ticket domain.Ticket
+	var attendeeName, attendeeBirthDate, spotId, spotName, spotZone sql.NullString
+	dest := []any{
+		&ticket.Id, &ticket.EventId, &ticket.OrderId, &ticket.TicketType, &ticket.Status, &ticket.Price, &attendeeName, &attendeeBirthDate, &ticket.HolderEmail, &ticket.CustomerId,
+		&spotId, &spotName, &spotZone,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if ticket.Attendee, err = parseAttendee(attendeeName, attendeeBirthDate); err != nil {
+		return nil, err
+	}
+	if spotId.Valid {
+		ticket.Spot = &domain.Spot{Id: spotId.String, EventId: ticket.EventId, Name: spotName.String, Zone: spotZone.String}
+	}
+	return &ticket, nil
+}
+
+// purchaseClaimTTL is how long a purchase in progress counts towards the
+// purchase limits. Failed purchases release their claim right away; this
+// only bounds claims left behind by a checkout that never finished.
+const purchaseClaimTTL = 15 * time.Minute
+
+// ClaimPurchase checks the purchase limits of the order's event against
+// the tickets its buyer already has plus the purchases they have in
+// progress, and records the order as in progress. The limits row of the
+// event is locked meanwhile, so concurrent checkouts of the same buyer are
+// counted one after the other instead of all passing. The claim lasts
+// until CreateOrder stores the order or ReleasePurchase drops it.
+func (r *mysqlOrderRepository) ClaimPurchase(limits *domain.PurchaseLimits, order *domain.Order, quantity int) error {
+	if !limits.TracksHistory() {
+		return limits.Check(quantity, domain.PurchaseHistory{})
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var eventId string
+	err = tx.QueryRow(`
+		SELECT event_id
+		FROM event_purchase_limits
+		WHERE event_id = ?
+		FOR UPDATE
+	`, order.EventId).Scan(&eventId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	history, err := findPurchaseHistory(tx, order.EventId, order.Email, order.CardHash, order.CreatedAt.Add(-limits.Window))
+	if err != nil {
+		return err
+	}
+	if err := limits.Check(quantity, *history); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO purchase_claims (order_id, event_id, email, card_hash, quantity, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, order.Id, order.EventId, order.Email, order.CardHash, quantity, order.CreatedAt.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReleasePurchase drops the claim of an order whose purchase failed.
+func (r *mysqlOrderRepository) ReleasePurchase(orderId string) error {
+	_, err := r.db.Exec(`DELETE FROM purchase_claims WHERE order_id = ?`, orderId)
+	return err
+}
+
+// findPurchaseHistory counts the active tickets of an event already bought
+// with the given email and card hash, and with the email since
+// windowStart, together with the tickets of purchases still in progress.
+func findPurchaseHistory(tx *sql.Tx, eventId, email, cardHash string, windowStart time.Time) (*domain.PurchaseHistory, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(quantity * (email = ?)), 0),
+			COALESCE(SUM(quantity * (card_hash = ? AND card_hash <> '')), 0),
+			COALESCE(SUM(quantity * (email = ? AND created_at >= ?)), 0)
+		FROM (
+			SELECT o.email, o.card_hash, o.created_at, 1 AS quantity
+			FROM tickets t
+			JOIN orders o ON o.id = t.order_id
+			WHERE o.event_id = ? AND t.status <> 'cancelled'
+			UNION ALL
+			SELECT email, card_hash, created_at, quantity
+			FROM purchase_claims
+			WHERE event_id = ? AND created_at >= ?
+		) purchases
+		WHERE email = ? OR (card_hash = ? AND card_hash <> '')
+	`
+	var history domain.PurchaseHistory
+	err := tx.QueryRow(query,
+		email, cardHash, email, windowStart.UTC().Format(dateTimeLayout),
+		eventId, eventId, time.Now().Add(-purchaseClaimTTL).UTC().Format(dateTimeLayout),
+		email, cardHash,
+	).Scan(&history.ByEmail, &history.ByCard, &history.ByEmailInWindow)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
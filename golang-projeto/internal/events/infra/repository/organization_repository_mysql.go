@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlOrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlOrganizationRepository creates a new MySQL organization repository.
+func NewMysqlOrganizationRepository(db *sql.DB) (domain.OrganizationRepository, error) {
+	return &mysqlOrganizationRepository{db: db}, nil
+}
+
+const organizationColumns = `o.id, o.slug, o.name, o.logo_url, o.primary_color, o.support_email, o.created_at`
+
+// FindOrganizationById returns an organization by its Id.
+func (r *mysqlOrganizationRepository) FindOrganizationById(organizationId string) (*domain.Organization, error) {
+	return r.findOrganization(`
+		SELECT `+organizationColumns+`
+		FROM organizations o
+		WHERE o.id = ?
+	`, organizationId)
+}
+
+// FindOrganizationBySlug returns an organization by its slug.
+func (r *mysqlOrganizationRepository) FindOrganizationBySlug(slug string) (*domain.Organization, error) {
+	return r.findOrganization(`
+		SELECT `+organizationColumns+`
+		FROM organizations o
+		WHERE o.slug = ?
+	`, slug)
+}
+
+// FindOrganizationByHost returns the organization serving a host.
+func (r *mysqlOrganizationRepository) FindOrganizationByHost(host string) (*domain.Organization, error) {
+	return r.findOrganization(`
+		SELECT `+organizationColumns+`
+		FROM organizations o
+		JOIN organization_hosts h ON h.organization_id = o.id
+		WHERE h.host = ?
+	`, host)
+}
+
+func (r *mysqlOrganizationRepository) findOrganization(query string, args ...any) (*domain.Organization, error) {
+	var organization domain.Organization
+	var createdAt string
+	err := r.db.QueryRow(query, args...).Scan(
+		&organization.Id, &organization.Slug, &organization.Name,
+		&organization.Branding.LogoURL, &organization.Branding.PrimaryColor, &organization.Branding.SupportEmail,
+		&createdAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	if organization.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	if organization.Hosts, err = queryStrings(r.db, `SELECT host FROM organization_hosts WHERE organization_id = ? ORDER BY host`, organization.Id); err != nil {
+		return nil, err
+	}
+	if organization.AdminUserIds, err = queryStrings(r.db, `SELECT user_id FROM organization_admins WHERE organization_id = ? ORDER BY user_id`, organization.Id); err != nil {
+		return nil, err
+	}
+	if organization.StaffUserIds, err = queryStrings(r.db, `SELECT user_id FROM organization_staff WHERE organization_id = ? ORDER BY user_id`, organization.Id); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT partner_id FROM organization_partners WHERE organization_id = ? ORDER BY partner_id`, organization.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var partnerId int
+		if err := rows.Scan(&partnerId); err != nil {
+			return nil, err
+		}
+		organization.PartnerIds = append(organization.PartnerIds, partnerId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func queryStrings(db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
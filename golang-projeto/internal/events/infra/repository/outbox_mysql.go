@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// insertDomainEvents writes events to the outbox. Callers pass the
+// transaction of the state change the events describe, so both are stored
+// or neither is.
+func insertDomainEvents(db execer, events []domain.DomainEvent) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return err
+		}
+		_, err = db.Exec(`
+			INSERT INTO domain_events (id, name, aggregate_id, event_id, payload, occurred_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, event.Id, event.Name, event.AggregateId, event.EventId, payload, event.OccurredAt.UTC().Format(dateTimeLayout))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mysqlDomainEventRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlDomainEventRepository creates the repository the relay uses to
+// read the outbox.
+func NewMysqlDomainEventRepository(db *sql.DB) (domain.DomainEventRepository, error) {
+	return &mysqlDomainEventRepository{db: db}, nil
+}
+
+// FindUnpublishedEvents returns up to limit events not published yet, in
+// the order they were stored.
+func (r *mysqlDomainEventRepository) FindUnpublishedEvents(limit int) ([]domain.DomainEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, name, aggregate_id, event_id, payload, occurred_at
+		FROM domain_events
+		WHERE published_at IS NULL
+		ORDER BY seq
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.DomainEvent
+	for rows.Next() {
+		var event domain.DomainEvent
+		var payload []byte
+		var occurredAt string
+		if err := rows.Scan(&event.Id, &event.Name, &event.AggregateId, &event.EventId, &payload, &occurredAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &event.Payload); err != nil {
+			return nil, err
+		}
+		if event.OccurredAt, err = parseDateTime(occurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// MarkEventPublished records that an event was delivered.
+func (r *mysqlDomainEventRepository) MarkEventPublished(eventId string, at time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE domain_events
+		SET published_at = ?
+		WHERE id = ?
+	`, at.UTC().Format(dateTimeLayout), eventId)
+	return err
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlTicketTransferRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlTicketTransferRepository creates a new MySQL ticket transfer repository.
+func NewMysqlTicketTransferRepository(db *sql.DB) (domain.TicketTransferRepository, error) {
+	return &mysqlTicketTransferRepository{db: db}, nil
+}
+
+const ticketTransferColumns = `id, ticket_id, from_email, to_email, token_hash, status, expires_at, created_at, accepted_at`
+
+// CreateTransfer inserts a new ticket transfer into the database.
+func (r *mysqlTicketTransferRepository) CreateTransfer(transfer *domain.TicketTransfer) error {
+	query := `
+		INSERT INTO ticket_transfers (` + ticketTransferColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(query,
+		transfer.Id, transfer.TicketId, transfer.FromEmail, transfer.ToEmail, transfer.TokenHash, transfer.Status,
+		transfer.ExpiresAt.UTC().Format(dateTimeLayout), transfer.CreatedAt.UTC().Format(dateTimeLayout), formatNullDateTime(transfer.AcceptedAt),
+	)
+	return err
+}
+
+// FindTransferByTokenHash returns the transfer matching a token hash.
+func (r *mysqlTicketTransferRepository) FindTransferByTokenHash(tokenHash string) (*domain.TicketTransfer, error) {
+	query := `
+		SELECT ` + ticketTransferColumns + `
+		FROM ticket_transfers
+		WHERE token_hash = ?
+	`
+	return scanTicketTransfer(r.db.QueryRow(query, tokenHash))
+}
+
+// FindPendingTransferByTicketId returns the pending transfer of a ticket.
+func (r *mysqlTicketTransferRepository) FindPendingTransferByTicketId(ticketId string) (*domain.TicketTransfer, error) {
+	query := `
+		SELECT ` + ticketTransferColumns + `
+		FROM ticket_transfers
+		WHERE ticket_id = ? AND status = ?
+		LIMIT 1
+	`
+	return scanTicketTransfer(r.db.QueryRow(query, ticketId, domain.TicketTransferStatusPending))
+}
+
+// UpdateTransfer stores the status of a ticket transfer.
+func (r *mysqlTicketTransferRepository) UpdateTransfer(transfer *domain.TicketTransfer) error {
+	query := `
+		UPDATE ticket_transfers
+		SET status = ?, accepted_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, transfer.Status, formatNullDateTime(transfer.AcceptedAt), transfer.Id)
+	return err
+}
+
+// AcceptTransfer stores an accepted transfer, moves the ticket to its new
+// holder and appends it to the holder history, all within a single
+// transaction. It fails if the transfer was concurrently accepted or the
+// ticket changed hands in the meantime.
+func (r *mysqlTicketTransferRepository) AcceptTransfer(transfer *domain.TicketTransfer, ticket *domain.Ticket) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE ticket_transfers
+		SET status = ?, accepted_at = ?
+		WHERE id = ? AND status = ?
+	`, transfer.Status, formatNullDateTime(transfer.AcceptedAt), transfer.Id, domain.TicketTransferStatusPending)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domain.ErrTicketTransferNotPending
+	}
+
+	result, err = tx.Exec(`
+		UPDATE tickets
+		SET holder_email = ?, customer_id = ?, credential_version = credential_version + 1
+		WHERE id = ? AND holder_email = ?
+	`, ticket.HolderEmail, ticket.CustomerId, ticket.Id, transfer.FromEmail)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return domain.ErrTicketTransferNotHolder
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO ticket_holders (ticket_id, email, transfer_id, since)
+		VALUES (?, ?, ?, ?)
+	`, ticket.Id, ticket.HolderEmail, transfer.Id, transfer.AcceptedAt.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return err
+	}
+
+	if err := insertDomainEvents(tx, ticket.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExpireTransfers marks every pending transfer expired at the given time
+// and returns how many were expired.
+func (r *mysqlTicketTransferRepository) ExpireTransfers(at time.Time) (int, error) {
+	query := `
+		UPDATE ticket_transfers
+		SET status = ?
+		WHERE status = ? AND expires_at <= ?
+	`
+	result, err := r.db.Exec(query, domain.TicketTransferStatusExpired, domain.TicketTransferStatusPending, at.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+// FindTicketHolders returns the holder history of a ticket, oldest first.
+func (r *mysqlTicketTransferRepository) FindTicketHolders(ticketId string) ([]domain.TicketHolder, error) {
+	query := `
+		SELECT ticket_id, email, transfer_id, since
+		FROM ticket_holders
+		WHERE ticket_id = ?
+		ORDER BY since, id
+	`
+	rows, err := r.db.Query(query, ticketId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holders []domain.TicketHolder
+	for rows.Next() {
+		var holder domain.TicketHolder
+		var since string
+		if err := rows.Scan(&holder.TicketId, &holder.Email, &holder.TransferId, &since); err != nil {
+			return nil, err
+		}
+		holder.Since, err = parseDateTime(since)
+		if err != nil {
+			return nil, err
+		}
+		holders = append(holders, holder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holders, nil
+}
+
+func scanTicketTransfer(row rowScanner) (*domain.TicketTransfer, error) {
+	var transfer domain.TicketTransfer
+	var expiresAt, createdAt string
+	var acceptedAt sql.NullString
+	err := row.Scan(
+		&transfer.Id, &transfer.TicketId, &transfer.FromEmail, &transfer.ToEmail, &transfer.TokenHash, &transfer.Status,
+		&expiresAt, &createdAt, &acceptedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTicketTransferNotFound
+		}
+		return nil, err
+	}
+
+	if transfer.ExpiresAt, err = parseDateTime(expiresAt); err != nil {
+		return nil, err
+	}
+	if transfer.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		if transfer.AcceptedAt, err = parseDateTime(acceptedAt.String); err != nil {
+			return nil, err
+		}
+	}
+
+	return &transfer, nil
+}
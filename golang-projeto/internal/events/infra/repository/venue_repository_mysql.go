@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlVenueRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlVenueRepository creates a new MySQL venue repository.
+func NewMysqlVenueRepository(db *sql.DB) (domain.VenueRepository, error) {
+	return &mysqlVenueRepository{db: db}, nil
+}
+
+const venueColumns = `id, organization_id, name, street, city, state, postal_code, country, latitude, longitude, timezone, capacity, created_at`
+
+// spotLayoutRecord is how a layout is stored in venue_layouts.layout.
+type spotLayoutRecord struct {
+	Rows        int                 `json:"rows"`
+	SeatsPerRow int                 `json:"seats_per_row"`
+	SkipRows    []string            `json:"skip_rows,omitempty"`
+	SkipSeats   []int               `json:"skip_seats,omitempty"`
+	Aisles      []int               `json:"aisles,omitempty"`
+	Zones       []layoutZoneRecord  `json:"zones,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+}
+
+type layoutZoneRecord struct {
+	Name     string `json:"name"`
+	FirstRow string `json:"first_row"`
+	LastRow  string `json:"last_row"`
+}
+
+// CreateVenue inserts a new venue and its layouts within a single
+// transaction.
+func (r *mysqlVenueRepository) CreateVenue(venue *domain.Venue) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO venues (`+venueColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, venue.Id, venue.OrganizationId, venue.Name,
+		venue.Address.Street, venue.Address.City, venue.Address.State, venue.Address.PostalCode, venue.Address.Country,
+		venue.Latitude, venue.Longitude, venue.Timezone, venue.Capacity, venue.CreatedAt.UTC().Format(dateTimeLayout),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range venue.Layouts {
+		if err := insertVenueLayout(tx, venue.Id, &venue.Layouts[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CreateVenueLayout stores a new layout of a venue.
+func (r *mysqlVenueRepository) CreateVenueLayout(venueId string, layout *domain.VenueLayout) error {
+	return insertVenueLayout(r.db, venueId, layout)
+}
+
+func insertVenueLayout(db execer, venueId string, layout *domain.VenueLayout) error {
+	record := spotLayoutRecord{
+		Rows:        layout.Layout.Rows,
+		SeatsPerRow: layout.Layout.SeatsPerRow,
+		SkipRows:    layout.Layout.SkipRows,
+		SkipSeats:   layout.Layout.SkipSeats,
+		Aisles:      layout.Layout.Aisles,
+	}
+	for _, zone := range layout.Layout.Zones {
+		record.Zones = append(record.Zones, layoutZoneRecord{Name: zone.Name, FirstRow: zone.FirstRow, LastRow: zone.LastRow})
+	}
+	if len(layout.Layout.Attributes) > 0 {
+		record.Attributes = make(map[string][]string, len(layout.Layout.Attributes))
+		for spot, attributes := range layout.Layout.Attributes {
+			for _, attribute := range attributes {
+				record.Attributes[spot] = append(record.Attributes[spot], string(attribute))
+			}
+		}
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`INSERT INTO venue_layouts (venue_id, name, layout) VALUES (?, ?, ?)`, venueId, layout.Name, data)
+	return err
+}
+
+// FindOrganizationVenue returns a venue of an organization with its layouts.
+func (r *mysqlVenueRepository) FindOrganizationVenue(organizationId, venueId string) (*domain.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE id = ? AND organization_id = ?
+	`
+	venue, err := scanVenue(r.db.QueryRow(query, venueId, organizationId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrVenueNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT name, layout FROM venue_layouts WHERE venue_id = ? ORDER BY name`, venue.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var layout domain.VenueLayout
+		var data []byte
+		if err := rows.Scan(&layout.Name, &data); err != nil {
+			return nil, err
+		}
+		if layout.Layout, err = parseSpotLayout(data); err != nil {
+			return nil, err
+		}
+		venue.Layouts = append(venue.Layouts, layout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// ListVenues returns the venues of an organization by name, without their
+// layouts.
+func (r *mysqlVenueRepository) ListVenues(organizationId string) ([]*domain.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE organization_id = ?
+		ORDER BY name, id
+	`
+	rows, err := r.db.Query(query, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var venues []*domain.Venue
+	for rows.Next() {
+		venue, err := scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		venues = append(venues, venue)
+	}
+	return venues, rows.Err()
+}
+
+// FindUpcomingVenueEvents returns the events at a venue starting after from.
+// Recurring events are listed through their sessions.
+func (r *mysqlVenueRepository) FindUpcomingVenueEvents(venueId string, from time.Time) ([]*domain.Event, error) {
+	rows, err := r.db.Query(`
+		SELECT id, parent_id, name, location, venue_id, organization_id, organization, rating, date, timezone, image_url, capacity, price, partner_id, seating_mode, sold_tickets, status, sales_start_at, sales_end_at
+		FROM events
+		WHERE venue_id = ? AND date >= ? AND recurrence IS NULL
+		ORDER BY date, id
+	`, venueId, from.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+	for rows.Next() {
+		var event domain.Event
+		var date string
+		var salesStartAt, salesEndAt sql.NullString
+		err := rows.Scan(
+			&event.Id, &event.ParentId, &event.Name, &event.Location, &event.VenueId, &event.OrganizationId, &event.Organization, &event.Rating, &date, &event.Timezone,
+			&event.ImageURL, &event.Capacity, &event.Price, &event.PartnerId, &event.SeatingMode, &event.SoldTickets, &event.Status, &salesStartAt, &salesEndAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if event.Date, err = parseDateTime(date); err != nil {
+			return nil, err
+		}
+		if err := setEventSchedule(&event, salesStartAt, salesEndAt, sql.NullString{}); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadSalesPhases(r.db, events...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func scanVenue(row rowScanner) (*domain.Venue, error) {
+	var venue domain.Venue
+	var createdAt string
+	err := row.Scan(
+		&venue.Id, &venue.OrganizationId, &venue.Name,
+		&venue.Address.Street, &venue.Address.City, &venue.Address.State, &venue.Address.PostalCode, &venue.Address.Country,
+		&venue.Latitude, &venue.Longitude, &venue.Timezone, &venue.Capacity, &createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if venue.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func parseSpotLayout(data []byte) (domain.SpotLayout, error) {
+	var record spotLayoutRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return domain.SpotLayout{}, err
+	}
+
+	layout := domain.SpotLayout{
+		Rows:        record.Rows,
+		SeatsPerRow: record.SeatsPerRow,
+		SkipRows:    record.SkipRows,
+		SkipSeats:   record.SkipSeats,
+		Aisles:      record.Aisles,
+	}
+	for _, zone := range record.Zones {
+		layout.Zones = append(layout.Zones, domain.LayoutZone{Name: zone.Name, FirstRow: zone.FirstRow, LastRow: zone.LastRow})
+	}
+	if len(record.Attributes) > 0 {
+		layout.Attributes = make(map[string][]domain.SpotAttribute, len(record.Attributes))
+		for spot, attributes := range record.Attributes {
+			for _, attribute := range attributes {
+				layout.Attributes[spot] = append(layout.Attributes[spot], domain.SpotAttribute(attribute))
+			}
+		}
+	}
+	return layout, nil
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlWaitingRoomRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlWaitingRoomRepository creates a new MySQL waiting room repository.
+func NewMysqlWaitingRoomRepository(db *sql.DB) (domain.WaitingRoomRepository, error) {
+	return &mysqlWaitingRoomRepository{db: db}, nil
+}
+
+const waitingRoomColumns = `event_id, active, admission_rate, admission_ttl_seconds, last_admission_at`
+
+const waitingRoomEntryColumns = `seq, token, event_id, user_id, status, joined_at, admitted_at, expires_at`
+
+// SaveWaitingRoom creates or replaces the waiting room of an event.
+func (r *mysqlWaitingRoomRepository) SaveWaitingRoom(room *domain.WaitingRoom) error {
+	query := `
+		INSERT INTO waiting_rooms (` + waitingRoomColumns + `)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			active = VALUES(active),
+			admission_rate = VALUES(admission_rate),
+			admission_ttl_seconds = VALUES(admission_ttl_seconds),
+			last_admission_at = VALUES(last_admission_at)
+	`
+	_, err := r.db.Exec(query,
+		room.EventId, room.Active, room.AdmissionRate, int(room.AdmissionTTL/time.Second),
+		room.LastAdmissionAt.UTC().Format(dateTimeLayout),
+	)
+	return err
+}
+
+// FindWaitingRoom returns the waiting room of an event.
+func (r *mysqlWaitingRoomRepository) FindWaitingRoom(eventId string) (*domain.WaitingRoom, error) {
+	return r.findWaitingRoom("w.event_id = ?", eventId)
+}
+
+// FindOrganizationWaitingRoom returns the waiting room of an event of an
+// organization.
+func (r *mysqlWaitingRoomRepository) FindOrganizationWaitingRoom(organizationId, eventId string) (*domain.WaitingRoom, error) {
+	return r.findWaitingRoom("w.event_id = ? AND e.organization_id = ?", eventId, organizationId)
+}
+
+func (r *mysqlWaitingRoomRepository) findWaitingRoom(condition string, args ...any) (*domain.WaitingRoom, error) {
+	query := `
+		SELECT w.event_id, w.active, w.admission_rate, w.admission_ttl_seconds, w.last_admission_at
+		FROM waiting_rooms w
+		JOIN events e ON e.id = w.event_id
+		WHERE ` + condition + `
+	`
+	room, err := scanWaitingRoom(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWaitingRoomNotFound
+		}
+		return nil, err
+	}
+	return room, nil
+}
+
+// FindActiveWaitingRooms returns the waiting rooms admitting customers.
+func (r *mysqlWaitingRoomRepository) FindActiveWaitingRooms() ([]*domain.WaitingRoom, error) {
+	query := `
+		SELECT ` + waitingRoomColumns + `
+		FROM waiting_rooms
+		WHERE active = TRUE
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []*domain.WaitingRoom
+	for rows.Next() {
+		room, err := scanWaitingRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, rows.Err()
+}
+
+// CreateWaitingRoomEntry puts a customer at the end of the queue, setting
+// entry.Seq.
+func (r *mysqlWaitingRoomRepository) CreateWaitingRoomEntry(entry *domain.WaitingRoomEntry) error {
+	query := `
+		INSERT INTO waiting_room_entries (token, event_id, status, joined_at, admitted_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.Exec(query,
+		entry.Token, entry.EventId, entry.Status, entry.JoinedAt.UTC().Format(dateTimeLayout),
+		formatNullDateTime(entry.AdmittedAt), formatNullDateTime(entry.ExpiresAt),
+	)
+	if err != nil {
+		return err
+	}
+	entry.Seq, err = result.LastInsertId()
+	return err
+}
+
+// FindWaitingRoomEntry returns an entry by its admission token.
+func (r *mysqlWaitingRoomRepository) FindWaitingRoomEntry(token string) (*domain.WaitingRoomEntry, error) {
+	query := `
+		SELECT ` + waitingRoomEntryColumns + `
+		FROM waiting_room_entries
+		WHERE token = ?
+	`
+	entry, err := scanWaitingRoomEntry(r.db.QueryRow(query, token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWaitingRoomEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// CountWaitingAhead returns how many customers of an event joined the
+// queue before seq and are still waiting.
+func (r *mysqlWaitingRoomRepository) CountWaitingAhead(eventId string, seq int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM waiting_room_entries
+		WHERE event_id = ? AND status = ? AND seq < ?
+	`
+	var count int
+	err := r.db.QueryRow(query, eventId, domain.WaitingRoomEntryWaiting, seq).Scan(&count)
+	return count, err
+}
+
+// CountWaiting returns how many customers of an event are still waiting.
+func (r *mysqlWaitingRoomRepository) CountWaiting(eventId string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM waiting_room_entries
+		WHERE event_id = ? AND status = ?
+	`
+	var count int
+	err := r.db.QueryRow(query, eventId, domain.WaitingRoomEntryWaiting).Scan(&count)
+	return count, err
+}
+
+// AdmitWaitingRoomEntries admits the first due waiting customers and moves
+// the admission clock of the room in the same transaction, so concurrent
+// workers never admit more than the rate allows.
+func (r *mysqlWaitingRoomRepository) AdmitWaitingRoomEntries(room *domain.WaitingRoom, due int, at time.Time) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Bloqueando a sala para serializar as admissões.
+	var lastAdmissionAt string
+	err = tx.QueryRow(`SELECT last_admission_at FROM waiting_rooms WHERE event_id = ? FOR UPDATE`, room.EventId).Scan(&lastAdmissionAt)
+	if err != nil {
+		return 0, err
+	}
+	if lastAdmissionAt != room.LastAdmissionAt.UTC().Format(dateTimeLayout) {
+		// Outro processo admitiu clientes desde a leitura da sala.
+		return 0, nil
+	}
+
+	result, err := tx.Exec(`
+		UPDATE waiting_room_entries
+		SET status = ?, admitted_at = ?, expires_at = ?
+		WHERE event_id = ? AND status = ?
+		ORDER BY seq
+		LIMIT ?
+	`, domain.WaitingRoomEntryAdmitted, at.UTC().Format(dateTimeLayout), at.Add(room.AdmissionTTL).UTC().Format(dateTimeLayout),
+		room.EventId, domain.WaitingRoomEntryWaiting, due)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	admitted := int(affected)
+
+	room.RecordAdmissions(admitted, due, at)
+	_, err = tx.Exec(`UPDATE waiting_rooms SET last_admission_at = ? WHERE event_id = ?`,
+		room.LastAdmissionAt.UTC().Format(dateTimeLayout), room.EventId)
+	if err != nil {
+		return 0, err
+	}
+	return admitted, tx.Commit()
+}
+
+// ConsumeWaitingRoomEntry marks an admitted entry as used by its customer.
+// The update only matches while the token is still admitted, unexpired and
+// not bound to someone else, so the same token never checks out twice.
+func (r *mysqlWaitingRoomRepository) ConsumeWaitingRoomEntry(entry *domain.WaitingRoomEntry) error {
+	query := `
+		UPDATE waiting_room_entries
+		SET status = ?, user_id = ?
+		WHERE token = ? AND status = ? AND expires_at > UTC_TIMESTAMP() AND (user_id = '' OR user_id = ?)
+	`
+	result, err := r.db.Exec(query, entry.Status, entry.UserId, entry.Token, domain.WaitingRoomEntryAdmitted, entry.UserId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAdmissionTokenUsed
+	}
+	return nil
+}
+
+// RestoreWaitingRoomEntry gives a consumed entry its admission back.
+func (r *mysqlWaitingRoomRepository) RestoreWaitingRoomEntry(token string) error {
+	query := `
+		UPDATE waiting_room_entries
+		SET status = ?
+		WHERE token = ? AND status = ?
+	`
+	_, err := r.db.Exec(query, domain.WaitingRoomEntryAdmitted, token, domain.WaitingRoomEntryConsumed)
+	return err
+}
+
+func scanWaitingRoom(row rowScanner) (*domain.WaitingRoom, error) {
+	var room domain.WaitingRoom
+	var ttlSeconds int
+	var lastAdmissionAt string
+	err := row.Scan(&room.EventId, &room.Active, &room.AdmissionRate, &ttlSeconds, &lastAdmissionAt)
+	if err != nil {
+		return nil, err
+	}
+	room.AdmissionTTL = time.Duration(ttlSeconds) * time.Second
+	if room.LastAdmissionAt, err = parseDateTime(lastAdmissionAt); err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func scanWaitingRoomEntry(row rowScanner) (*domain.WaitingRoomEntry, error) {
+	var entry domain.WaitingRoomEntry
+	var joinedAt string
+	var admittedAt, expiresAt sql.NullString
+	err := row.Scan(&entry.Seq, &entry.Token, &entry.EventId, &entry.UserId, &entry.Status, &joinedAt, &admittedAt, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.JoinedAt, err = parseDateTime(joinedAt); err != nil {
+		return nil, err
+	}
+	if admittedAt.Valid {
+		if entry.AdmittedAt, err = parseDateTime(admittedAt.String); err != nil {
+			return nil, err
+		}
+	}
+	if expiresAt.Valid {
+		if entry.ExpiresAt, err = parseDateTime(expiresAt.String); err != nil {
+			return nil, err
+		}
+	}
+	return &entry, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlWaitlistRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlWaitlistRepository creates a new MySQL waitlist repository.
+func NewMysqlWaitlistRepository(db *sql.DB) (domain.WaitlistRepository, error) {
+	return &mysqlWaitlistRepository{db: db}, nil
+}
+
+const waitlistColumns = `id, event_id, email, quantity, ticket_type, status, hold_id, offer_expires_at, created_at`
+
+// CreateWaitlistEntry inserts a new waitlist entry into the database.
+func (r *mysqlWaitlistRepository) CreateWaitlistEntry(entry *domain.WaitlistEntry) error {
+	query := `
+		INSERT INTO waitlist_entries (` + waitlistColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(query,
+		entry.Id, entry.EventId, entry.Email, entry.Quantity, entry.TicketType, entry.Status,
+		entry.HoldId, formatNullDateTime(entry.OfferExpiresAt), entry.CreatedAt.UTC().Format(dateTimeLayout),
+	)
+	return err
+}
+
+// UpdateWaitlistEntry stores the status and offer of a waitlist entry.
+func (r *mysqlWaitlistRepository) UpdateWaitlistEntry(entry *domain.WaitlistEntry) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = ?, hold_id = ?, offer_expires_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, entry.Status, entry.HoldId, formatNullDateTime(entry.OfferExpiresAt), entry.Id)
+	return err
+}
+
+// FindWaitlistEntryByEmail returns the waiting or offered entry of an email
+// for an event.
+func (r *mysqlWaitlistRepository) FindWaitlistEntryByEmail(eventId, email string) (*domain.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE event_id = ? AND email = ? AND status IN (?, ?)
+		LIMIT 1
+	`
+	return scanWaitlistEntry(r.db.QueryRow(query, eventId, email, domain.WaitlistStatusWaiting, domain.WaitlistStatusOffered))
+}
+
+// FindNextWaitlistEntry returns the oldest waiting entry of an event.
+func (r *mysqlWaitlistRepository) FindNextWaitlistEntry(eventId string) (*domain.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE event_id = ? AND status = ?
+		ORDER BY created_at, id
+		LIMIT 1
+	`
+	return scanWaitlistEntry(r.db.QueryRow(query, eventId, domain.WaitlistStatusWaiting))
+}
+
+// FindExpiredWaitlistOffers returns the offered entries whose offer expired
+// at the given time.
+func (r *mysqlWaitlistRepository) FindExpiredWaitlistOffers(at time.Time) ([]*domain.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE status = ? AND offer_expires_at <= ?
+	`
+	rows, err := r.db.Query(query, domain.WaitlistStatusOffered, at.UTC().Format(dateTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.WaitlistEntry
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWaitlistEntry(row rowScanner) (*domain.WaitlistEntry, error) {
+	var entry domain.WaitlistEntry
+	var offerExpiresAt sql.NullString
+	var createdAt string
+	err := row.Scan(&entry.Id, &entry.EventId, &entry.Email, &entry.Quantity, &entry.TicketType, &entry.Status, &entry.HoldId, &offerExpiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWaitlistEntryNotFound
+		}
+		return nil, err
+	}
+
+	entry.CreatedAt, err = parseDateTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if offerExpiresAt.Valid {
+		entry.OfferExpiresAt, err = parseDateTime(offerExpiresAt.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
+
+// formatNullDateTime stores zero times as NULL.
+func formatNullDateTime(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.UTC().Format(dateTimeLayout), Valid: true}
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type mysqlWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlWebhookRepository creates a new MySQL webhook repository.
+func NewMysqlWebhookRepository(db *sql.DB) (domain.WebhookRepository, error) {
+	return &mysqlWebhookRepository{db: db}, nil
+}
+
+const webhookSubscriptionColumns = `id, organization_id, url, secret, event_types, active, created_at`
+
+const webhookDeliveryColumns = `id, subscription_id, domain_event_id, event_name, body, status, attempts, last_status_code, last_error, created_at, next_attempt_at, delivered_at`
+
+// CreateSubscription registers a new webhook subscription.
+func (r *mysqlWebhookRepository) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.db.Exec(query,
+		subscription.Id, subscription.OrganizationId, subscription.URL, subscription.Secret, eventTypes, subscription.Active,
+		subscription.CreatedAt.UTC().Format(dateTimeLayout),
+	)
+	return err
+}
+
+// FindSubscriptionById returns a webhook subscription by its ID.
+func (r *mysqlWebhookRepository) FindSubscriptionById(subscriptionId string) (*domain.WebhookSubscription, error) {
+	return r.findSubscription("id = ?", subscriptionId)
+}
+
+// FindOrganizationSubscription returns a webhook subscription of an
+// organization by its ID.
+func (r *mysqlWebhookRepository) FindOrganizationSubscription(organizationId, subscriptionId string) (*domain.WebhookSubscription, error) {
+	return r.findSubscription("id = ? AND organization_id = ?", subscriptionId, organizationId)
+}
+
+func (r *mysqlWebhookRepository) findSubscription(condition string, args ...any) (*domain.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE ` + condition
+	subscription, err := scanWebhookSubscription(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// FindEventSubscriptions returns the subscriptions of the event's
+// organization receiving deliveries.
+func (r *mysqlWebhookRepository) FindEventSubscriptions(eventId string) ([]*domain.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE active = TRUE AND organization_id = (SELECT organization_id FROM events WHERE id = ?)
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(query, eventId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.WebhookSubscription
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// CreateDeliveries queues deliveries. A delivery of a domain event already
+// queued for the same subscription is silently ignored, so the fan-out of
+// an event can safely run again.
+func (r *mysqlWebhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT IGNORE INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, delivery := range deliveries {
+		_, err := tx.Exec(query,
+			delivery.Id, delivery.SubscriptionId, delivery.DomainEventId, delivery.EventName, delivery.Body,
+			delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError,
+			delivery.CreatedAt.UTC().Format(dateTimeLayout), delivery.NextAttemptAt.UTC().Format(dateTimeLayout),
+			formatNullDateTime(delivery.DeliveredAt),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FindDeliveryById returns a webhook delivery by its ID.
+func (r *mysqlWebhookRepository) FindDeliveryById(deliveryId string) (*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = ?
+	`
+	delivery, err := scanWebhookDelivery(r.db.QueryRow(query, deliveryId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// FindDeliveries returns up to limit deliveries of a subscription, newest
+// first, optionally only those with the given status.
+func (r *mysqlWebhookRepository) FindDeliveries(subscriptionId string, status domain.WebhookDeliveryStatus, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = ? AND (? = '' OR status = ?)
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, subscriptionId, status, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanWebhookDeliveries(rows)
+}
+
+// FindDueDeliveries returns up to limit pending deliveries whose next
+// attempt is due, oldest first.
+func (r *mysqlWebhookRepository) FindDueDeliveries(at time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, created_at
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, domain.WebhookDeliveryPending, at.UTC().Format(dateTimeLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanWebhookDeliveries(rows)
+}
+
+// UpdateDelivery stores the delivery state of a webhook delivery.
+func (r *mysqlWebhookRepository) UpdateDelivery(delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query,
+		delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError,
+		delivery.NextAttemptAt.UTC().Format(dateTimeLayout), formatNullDateTime(delivery.DeliveredAt),
+		delivery.Id,
+	)
+	return err
+}
+
+func scanWebhookSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	var eventTypes []byte
+	var createdAt string
+	err := row.Scan(&subscription.Id, &subscription.OrganizationId, &subscription.URL, &subscription.Secret, &eventTypes, &subscription.Active, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventTypes, &subscription.EventTypes); err != nil {
+		return nil, err
+	}
+	if subscription.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhookDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var createdAt, nextAttemptAt string
+	var deliveredAt sql.NullString
+	err := row.Scan(
+		&delivery.Id, &delivery.SubscriptionId, &delivery.DomainEventId, &delivery.EventName, &delivery.Body,
+		&delivery.Status, &delivery.Attempts, &delivery.LastStatusCode, &delivery.LastError,
+		&createdAt, &nextAttemptAt, &deliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if delivery.NextAttemptAt, err = parseDateTime(nextAttemptAt); err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		if delivery.DeliveredAt, err = parseDateTime(deliveredAt.String); err != nil {
+			return nil, err
+		}
+	}
+	return &delivery, nil
+}
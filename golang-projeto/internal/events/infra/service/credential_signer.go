@@ -0,0 +1,256 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// Ed25519CredentialSigner signs ticket credentials as
+//
+//	<key id>.<base64url JSON payload>.<base64url signature>
+//
+// The signature covers both the key id and the payload. New credentials
+// are signed with the active key; credentials signed with any other known
+// key, including retired keys for which only the public half is kept, are
+// still accepted by Verify. Check-in manifests share the format; the typ
+// field of the payload tells them apart from ticket credentials.
+type Ed25519CredentialSigner struct {
+	activeKeyId string
+	privateKeys map[string]ed25519.PrivateKey
+	publicKeys  map[string]ed25519.PublicKey
+}
+
+const (
+	credentialPayloadType = "ticket"
+	manifestPayloadType   = "manifest"
+)
+
+type credentialPayload struct {
+	Type       string `json:"typ"`
+	TicketId   string `json:"tid"`
+	EventId    string `json:"eid"`
+	Spot       string `json:"spot,omitempty"`
+	Version    int    `json:"ver,omitempty"`
+	IssuedAt   int64  `json:"iat"`
+	ValidUntil int64  `json:"exp"`
+}
+
+type manifestPayload struct {
+	Type       string                  `json:"typ"`
+	EventId    string                  `json:"eid"`
+	Tickets    []manifestTicketPayload `json:"tickets"`
+	IssuedAt   int64                   `json:"iat"`
+	ValidUntil int64                   `json:"exp"`
+}
+
+type manifestTicketPayload struct {
+	TicketId string `json:"tid"`
+	Version  int    `json:"ver,omitempty"`
+}
+
+var (
+	errCredentialKeyIdRequired = errors.New("credential key id is required")
+	errCredentialActiveKey     = errors.New("active credential key id has no private key")
+)
+
+// NewEd25519CredentialSigner builds a signer from private keys, which both
+// sign and verify, and public keys, which only verify.
+func NewEd25519CredentialSigner(activeKeyId string, privateKeys map[string]ed25519.PrivateKey, publicKeys map[string]ed25519.PublicKey) (*Ed25519CredentialSigner, error) {
+	if _, ok := privateKeys[activeKeyId]; !ok {
+		return nil, errCredentialActiveKey
+	}
+
+	s := &Ed25519CredentialSigner{
+		activeKeyId: activeKeyId,
+		privateKeys: privateKeys,
+		publicKeys:  make(map[string]ed25519.PublicKey, len(privateKeys)+len(publicKeys)),
+	}
+	for keyId, key := range publicKeys {
+		s.publicKeys[keyId] = key
+	}
+	for keyId, key := range privateKeys {
+		if keyId == "" || strings.Contains(keyId, ".") {
+			return nil, fmt.Errorf("invalid credential key id %q", keyId)
+		}
+		s.publicKeys[keyId] = key.Public().(ed25519.PublicKey)
+	}
+	return s, nil
+}
+
+// ParseEd25519PrivateKeys parses "kid:base64seed,kid:base64seed", where each
+// seed holds the 32 bytes of an Ed25519 private key seed.
+func ParseEd25519PrivateKeys(spec string) (map[string]ed25519.PrivateKey, error) {
+	keys := make(map[string]ed25519.PrivateKey)
+	err := parseCredentialKeys(spec, ed25519.SeedSize, func(keyId string, raw []byte) {
+		keys[keyId] = ed25519.NewKeyFromSeed(raw)
+	})
+	return keys, err
+}
+
+// ParseEd25519PublicKeys parses "kid:base64key,kid:base64key".
+func ParseEd25519PublicKeys(spec string) (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey)
+	err := parseCredentialKeys(spec, ed25519.PublicKeySize, func(keyId string, raw []byte) {
+		keys[keyId] = ed25519.PublicKey(raw)
+	})
+	return keys, err
+}
+
+func parseCredentialKeys(spec string, size int, add func(keyId string, raw []byte)) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		keyId, encoded, ok := strings.Cut(entry, ":")
+		if !ok || keyId == "" {
+			return errCredentialKeyIdRequired
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("credential key %q: %w", keyId, err)
+		}
+		if len(raw) != size {
+			return fmt.Errorf("credential key %q must have %d bytes", keyId, size)
+		}
+		add(keyId, raw)
+	}
+	return nil
+}
+
+func (s *Ed25519CredentialSigner) Sign(credential *domain.TicketCredential) (string, error) {
+	signed, err := s.sign(credentialPayload{
+		Type:       credentialPayloadType,
+		TicketId:   credential.TicketId,
+		EventId:    credential.EventId,
+		Spot:       credential.Spot,
+		Version:    credential.Version,
+		IssuedAt:   credential.IssuedAt.Unix(),
+		ValidUntil: credential.ValidUntil.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	credential.KeyId = s.activeKeyId
+	return signed, nil
+}
+
+// SignManifest signs a check-in manifest in the same format as ticket
+// credentials, so gate devices verify both with the same keys.
+func (s *Ed25519CredentialSigner) SignManifest(manifest *domain.CheckInManifest) (string, error) {
+	tickets := make([]manifestTicketPayload, len(manifest.Tickets))
+	for i, ticket := range manifest.Tickets {
+		tickets[i] = manifestTicketPayload{TicketId: ticket.TicketId, Version: ticket.CredentialVersion}
+	}
+	signed, err := s.sign(manifestPayload{
+		Type:       manifestPayloadType,
+		EventId:    manifest.EventId,
+		Tickets:    tickets,
+		IssuedAt:   manifest.IssuedAt.Unix(),
+		ValidUntil: manifest.ValidUntil.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	manifest.KeyId = s.activeKeyId
+	return signed, nil
+}
+
+func (s *Ed25519CredentialSigner) PublicKeys() map[string][]byte {
+	keys := make(map[string][]byte, len(s.publicKeys))
+	for keyId, key := range s.publicKeys {
+		keys[keyId] = []byte(key)
+	}
+	return keys
+}
+
+func (s *Ed25519CredentialSigner) sign(payload any) (string, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signed := s.activeKeyId + "." + base64.RawURLEncoding.EncodeToString(raw)
+	signature := ed25519.Sign(s.privateKeys[s.activeKeyId], []byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (s *Ed25519CredentialSigner) Verify(signed string) (*domain.TicketCredential, error) {
+	var payload credentialPayload
+	keyId, err := s.verify(signed, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Type != credentialPayloadType || payload.TicketId == "" {
+		return nil, domain.ErrTicketCredentialInvalid
+	}
+
+	return &domain.TicketCredential{
+		KeyId:      keyId,
+		TicketId:   payload.TicketId,
+		EventId:    payload.EventId,
+		Spot:       payload.Spot,
+		Version:    payload.Version,
+		IssuedAt:   time.Unix(payload.IssuedAt, 0),
+		ValidUntil: time.Unix(payload.ValidUntil, 0),
+	}, nil
+}
+
+// VerifyManifest is Verify for check-in manifests, failing with the same
+// errors as ticket credentials.
+func (s *Ed25519CredentialSigner) VerifyManifest(signed string) (*domain.CheckInManifest, error) {
+	var payload manifestPayload
+	keyId, err := s.verify(signed, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Type != manifestPayloadType || payload.EventId == "" {
+		return nil, domain.ErrTicketCredentialInvalid
+	}
+
+	tickets := make([]domain.CheckInManifestTicket, len(payload.Tickets))
+	for i, ticket := range payload.Tickets {
+		tickets[i] = domain.CheckInManifestTicket{TicketId: ticket.TicketId, CredentialVersion: ticket.Version}
+	}
+	return &domain.CheckInManifest{
+		KeyId:      keyId,
+		EventId:    payload.EventId,
+		Tickets:    tickets,
+		IssuedAt:   time.Unix(payload.IssuedAt, 0),
+		ValidUntil: time.Unix(payload.ValidUntil, 0),
+	}, nil
+}
+
+// verify checks the signature of signed and decodes its payload, returning
+// the id of the key that signed it.
+func (s *Ed25519CredentialSigner) verify(signed string, payload any) (string, error) {
+	parts := strings.Split(signed, ".")
+	if len(parts) != 3 {
+		return "", domain.ErrTicketCredentialInvalid
+	}
+
+	key, ok := s.publicKeys[parts[0]]
+	if !ok {
+		return "", domain.ErrTicketCredentialUnknownKey
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return "", domain.ErrTicketCredentialInvalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", domain.ErrTicketCredentialInvalid
+	}
+	if err := json.Unmarshal(raw, payload); err != nil {
+		return "", domain.ErrTicketCredentialInvalid
+	}
+	return parts[0], nil
+}
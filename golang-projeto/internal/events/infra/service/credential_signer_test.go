@@ -0,0 +1,157 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+func testKey(seed byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed([]byte(strings.Repeat(string(rune(seed)), ed25519.SeedSize)))
+}
+
+func testSigner(t *testing.T) *Ed25519CredentialSigner {
+	t.Helper()
+	signer, err := NewEd25519CredentialSigner("k2",
+		map[string]ed25519.PrivateKey{"k2": testKey('b')},
+		map[string]ed25519.PublicKey{"k1": testKey('a').Public().(ed25519.PublicKey)})
+	if err != nil {
+		t.Fatalf("NewEd25519CredentialSigner() error = %v", err)
+	}
+	return signer
+}
+
+func testCredential() *domain.TicketCredential {
+	return &domain.TicketCredential{
+		TicketId:   "ticket",
+		EventId:    "event",
+		Spot:       "A1",
+		Version:    2,
+		IssuedAt:   time.Unix(1900000000, 0),
+		ValidUntil: time.Unix(1900086400, 0),
+	}
+}
+
+func TestEd25519CredentialSignerRoundTrip(t *testing.T) {
+	signer := testSigner(t)
+	credential := testCredential()
+	signed, err := signer.Sign(credential)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "k2.") || credential.KeyId != "k2" {
+		t.Errorf("Sign() = %q with key %q, want the active key", signed, credential.KeyId)
+	}
+
+	verified, err := signer.Verify(signed)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if *verified != *credential {
+		t.Errorf("Verify() = %+v, want %+v", verified, credential)
+	}
+
+	manifest := &domain.CheckInManifest{
+		EventId: "event",
+		Tickets: []domain.CheckInManifestTicket{
+			{TicketId: "first", CredentialVersion: 0},
+			{TicketId: "second", CredentialVersion: 3},
+		},
+		IssuedAt:   time.Unix(1900000000, 0),
+		ValidUntil: time.Unix(1900003600, 0),
+	}
+	signedManifest, err := signer.SignManifest(manifest)
+	if err != nil {
+		t.Fatalf("SignManifest() error = %v", err)
+	}
+	verifiedManifest, err := signer.VerifyManifest(signedManifest)
+	if err != nil {
+		t.Fatalf("VerifyManifest() error = %v", err)
+	}
+	if verifiedManifest.EventId != "event" || len(verifiedManifest.Tickets) != 2 || verifiedManifest.Tickets[1] != manifest.Tickets[1] {
+		t.Errorf("VerifyManifest() = %+v, want %+v", verifiedManifest, manifest)
+	}
+}
+
+func TestEd25519CredentialSignerRejects(t *testing.T) {
+	signer := testSigner(t)
+	credential, err := signer.Sign(testCredential())
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	manifest, err := signer.SignManifest(&domain.CheckInManifest{EventId: "event", Tickets: []domain.CheckInManifestTicket{{TicketId: "ticket"}}})
+	if err != nil {
+		t.Fatalf("SignManifest() error = %v", err)
+	}
+	untyped, err := signer.sign(credentialPayload{TicketId: "ticket", EventId: "event"})
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	retired, err := NewEd25519CredentialSigner("k1", map[string]ed25519.PrivateKey{"k1": testKey('a')}, nil)
+	if err != nil {
+		t.Fatalf("NewEd25519CredentialSigner() error = %v", err)
+	}
+	retiredCredential, err := retired.Sign(testCredential())
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	unknown, err := NewEd25519CredentialSigner("k3", map[string]ed25519.PrivateKey{"k3": testKey('c')}, nil)
+	if err != nil {
+		t.Fatalf("NewEd25519CredentialSigner() error = %v", err)
+	}
+	unknownCredential, err := unknown.Sign(testCredential())
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	parts := strings.Split(credential, ".")
+	tests := []struct {
+		name   string
+		signed string
+		err    error
+	}{
+		{name: "retired key", signed: retiredCredential},
+		{name: "unknown key", signed: unknownCredential, err: domain.ErrTicketCredentialUnknownKey},
+		{name: "manifest as credential", signed: manifest, err: domain.ErrTicketCredentialInvalid},
+		{name: "untyped payload", signed: untyped, err: domain.ErrTicketCredentialInvalid},
+		{name: "key swapped", signed: "k1." + parts[1] + "." + parts[2], err: domain.ErrTicketCredentialInvalid},
+		{name: "payload tampered", signed: parts[0] + "." + parts[1] + "x." + parts[2], err: domain.ErrTicketCredentialInvalid},
+		{name: "malformed", signed: "k2.payload", err: domain.ErrTicketCredentialInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.signed); !errors.Is(err, tt.err) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	if _, err := signer.VerifyManifest(credential); !errors.Is(err, domain.ErrTicketCredentialInvalid) {
+		t.Errorf("VerifyManifest() of a credential error = %v, want %v", err, domain.ErrTicketCredentialInvalid)
+	}
+}
+
+func TestNewEd25519CredentialSigner(t *testing.T) {
+	tests := []struct {
+		name        string
+		activeKeyId string
+		privateKeys map[string]ed25519.PrivateKey
+	}{
+		{name: "no active key", activeKeyId: "k2", privateKeys: map[string]ed25519.PrivateKey{"k1": testKey('a')}},
+		{name: "dotted key id", activeKeyId: "k.1", privateKeys: map[string]ed25519.PrivateKey{"k.1": testKey('a')}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEd25519CredentialSigner(tt.activeKeyId, tt.privateKeys, nil); err == nil {
+				t.Errorf("NewEd25519CredentialSigner() error = nil")
+			}
+		})
+	}
+}
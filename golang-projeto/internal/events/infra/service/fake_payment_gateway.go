@@ -0,0 +1,124 @@
+package service
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/google/uuid"
+)
+
+// DeclinedCardHashPrefix makes FakePaymentGateway decline a card, so the
+// failure path of checkout can be exercised by hand.
+const DeclinedCardHashPrefix = "declined"
+
+// FakePaymentGateway is an in-memory PaymentGateway for development. It
+// approves every card except those whose hash starts with
+// DeclinedCardHashPrefix, and forgets everything on restart.
+type FakePaymentGateway struct {
+	mu             sync.Mutex
+	authorizations map[string]*fakeAuthorization
+	references     map[string]string
+}
+
+type fakeAuthorization struct {
+	amount   float64
+	captured float64
+	refunded float64
+	status   domain.PaymentStatus
+}
+
+func NewFakePaymentGateway() *FakePaymentGateway {
+	return &FakePaymentGateway{
+		authorizations: make(map[string]*fakeAuthorization),
+		references:     make(map[string]string),
+	}
+}
+
+func (g *FakePaymentGateway) Authorize(cardHash string, amount float64, reference string) (string, error) {
+	if cardHash == "" {
+		return "", domain.ErrPaymentCardHashRequired
+	}
+	if amount <= 0 {
+		return "", domain.ErrPaymentInvalidAmount
+	}
+	if strings.HasPrefix(cardHash, DeclinedCardHashPrefix) {
+		return "", domain.ErrPaymentDeclined
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if authorizationId, ok := g.references[reference]; ok {
+		return authorizationId, nil
+	}
+
+	authorizationId := "fake_" + uuid.New().String()
+	g.authorizations[authorizationId] = &fakeAuthorization{amount: domain.RoundAmount(amount), status: domain.PaymentStatusAuthorized}
+	if reference != "" {
+		g.references[reference] = authorizationId
+	}
+	return authorizationId, nil
+}
+
+func (g *FakePaymentGateway) Capture(authorizationId string, amount float64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authorization, ok := g.authorizations[authorizationId]
+	if !ok {
+		return domain.ErrPaymentNotFound
+	}
+	if authorization.status != domain.PaymentStatusAuthorized {
+		return domain.ErrPaymentInvalidState
+	}
+	if amount <= 0 {
+		return domain.ErrPaymentInvalidAmount
+	}
+	if domain.RoundAmount(amount) > authorization.amount {
+		return domain.ErrPaymentAmountExceeded
+	}
+
+	authorization.captured = domain.RoundAmount(amount)
+	authorization.status = domain.PaymentStatusCaptured
+	return nil
+}
+
+func (g *FakePaymentGateway) Void(authorizationId string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authorization, ok := g.authorizations[authorizationId]
+	if !ok {
+		return domain.ErrPaymentNotFound
+	}
+	if authorization.status != domain.PaymentStatusAuthorized {
+		return domain.ErrPaymentInvalidState
+	}
+
+	authorization.status = domain.PaymentStatusVoided
+	return nil
+}
+
+func (g *FakePaymentGateway) Refund(authorizationId string, amount float64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authorization, ok := g.authorizations[authorizationId]
+	if !ok {
+		return domain.ErrPaymentNotFound
+	}
+	if authorization.status != domain.PaymentStatusCaptured && authorization.status != domain.PaymentStatusRefunded {
+		return domain.ErrPaymentInvalidState
+	}
+	if amount <= 0 {
+		return domain.ErrPaymentInvalidAmount
+	}
+	if domain.RoundAmount(authorization.refunded+amount) > authorization.captured {
+		return domain.ErrPaymentAmountExceeded
+	}
+
+	authorization.refunded = domain.RoundAmount(authorization.refunded + amount)
+	authorization.status = domain.PaymentStatusRefunded
+	return nil
+}
@@ -3,6 +3,7 @@ package service
 type ReservationRequest struct {
 	EventId    string   `json:"event_id"`
 	Spots      []string `json:"spots"`
+	Quantity   int      `json:"quantity"`
 	TicketType string   `json:"ticket_type"`
 	CardHash   string   `json:"card_hash"`
 	Email      string   `json:"email"`
@@ -20,3 +21,18 @@ type ReservationResponse struct {
 type Partner interface {
 	MakeReservation(req *ReservationRequest) ([]ReservationResponse, error)
 }
+
+// TransferNotification tells a partner that a ticket changed hands.
+type TransferNotification struct {
+	EventId   string
+	Spot      string
+	FromEmail string
+	ToEmail   string
+}
+
+// TransferNotifier is implemented by partners that want to be told about
+// ticket transfers. It is optional: partners that do not implement it are
+// simply not notified.
+type TransferNotifier interface {
+	NotifyTransfer(notification *TransferNotification) error
+}
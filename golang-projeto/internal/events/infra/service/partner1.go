@@ -12,12 +12,19 @@ type Partner1 struct {
 }
 
 type Partner1ReservationRequest struct {
-	Spots      []string `json:"spots"`
+	Spots      []string `json:"spots,omitempty"`
+	Quantity   int      `json:"quantity,omitempty"`
 	TicketKind string   `json:"ticket_kind"`
 	Email      string   `json:"email"`
 	EventId    string   `json:"event_id"`
 }
 
+type Partner1TransferRequest struct {
+	Spot      string `json:"spot"`
+	FromEmail string `json:"from_email"`
+	ToEmail   string `json:"to_email"`
+}
+
 type Partner1ReservationResponse struct {
 	Id         string `json:"id"`
 	Email      string `json:"email"`
@@ -30,6 +37,7 @@ type Partner1ReservationResponse struct {
 func (p *Partner1) MakeReservation(req *ReservationRequest) ([]ReservationResponse, error) {
 	partnerRequest := Partner1ReservationRequest{
 		Spots:      req.Spots,
+		Quantity:   req.Quantity,
 		TicketKind: req.TicketType,
 		Email:      req.Email,
 	}
@@ -80,3 +88,28 @@ func (p *Partner1) MakeReservation(req *ReservationRequest) ([]ReservationRespon
 	return responses, nil
 
 }
+
+func (p *Partner1) NotifyTransfer(notification *TransferNotification) error {
+	partnerRequest := Partner1TransferRequest{
+		Spot:      notification.Spot,
+		FromEmail: notification.FromEmail,
+		ToEmail:   notification.ToEmail,
+	}
+
+	body, err := json.Marshal(partnerRequest)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/events/%s/transfers", p.BaseURL, notification.EventId)
+	httpRespose, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer httpRespose.Body.Close()
+
+	if httpRespose.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: %d", httpRespose.StatusCode)
+	}
+	return nil
+}
@@ -12,12 +12,19 @@ type Partner2 struct {
 }
 
 type Partner2ReservationRequest struct {
-	Lugares      []string `json:"lugares"`
+	Lugares      []string `json:"lugares,omitempty"`
+	Quantidade   int      `json:"quantidade,omitempty"`
 	TipoIngresso string   `json:"tipo_ingresso"`
 	Email        string   `json:"email"`
 	EventId      string   `json:"event_id"`
 }
 
+type Partner2TransferRequest struct {
+	Lugar        string `json:"lugar"`
+	EmailOrigem  string `json:"email_origem"`
+	EmailDestino string `json:"email_destino"`
+}
+
 type Partner2ReservationResponse struct {
 	Id           string `json:"id"`
 	Email        string `json:"email"`
@@ -30,6 +37,7 @@ type Partner2ReservationResponse struct {
 func (p *Partner2) MakeReservation(req *ReservationRequest) ([]ReservationResponse, error) {
 	partnerRequest := Partner2ReservationRequest{
 		Lugares:      req.Spots,
+		Quantidade:   req.Quantity,
 		TipoIngresso: req.TicketType,
 		Email:        req.Email,
 	}
@@ -80,3 +88,28 @@ func (p *Partner2) MakeReservation(req *ReservationRequest) ([]ReservationRespon
 	return responses, nil
 
 }
+
+func (p *Partner2) NotifyTransfer(notification *TransferNotification) error {
+	partnerRequest := Partner2TransferRequest{
+		Lugar:        notification.Spot,
+		EmailOrigem:  notification.FromEmail,
+		EmailDestino: notification.ToEmail,
+	}
+
+	body, err := json.Marshal(partnerRequest)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/eventos/%s/transferencias", p.BaseURL, notification.EventId)
+	httpRespose, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer httpRespose.Body.Close()
+
+	if httpRespose.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: %d", httpRespose.StatusCode)
+	}
+	return nil
+}
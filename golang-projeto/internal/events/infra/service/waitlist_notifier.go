@@ -0,0 +1,26 @@
+package service
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// LogWaitlistNotifier writes waitlist offers to the application log.
+type LogWaitlistNotifier struct{}
+
+func NewLogWaitlistNotifier() domain.WaitlistNotifier {
+	return &LogWaitlistNotifier{}
+}
+
+func (n *LogWaitlistNotifier) NotifyWaitlistOffer(entry *domain.WaitlistEntry, event *domain.Event, hold *domain.Hold) error {
+	spots := "general admission"
+	if len(hold.Spots) > 0 {
+		spots = strings.Join(hold.SpotNames(), ", ")
+	}
+	log.Printf("waitlist offer to %s for event %q (%s): %d ticket(s) [%s], hold %q valid until %s",
+		entry.Email, event.Name, event.Id, entry.Quantity, spots, entry.HoldId, entry.OfferExpiresAt.UTC().Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+	"github.com/daffc/imersao18/golang/internal/events/infra/service"
+)
+
+type AcceptTicketTransferInputDTO struct {
+	OrganizationId string `json:"-"`
+	Token          string `json:"token"`
+}
+
+// AcceptTicketTransferUseCase completes a transfer. When notifyPartners is
+// set, partners implementing service.TransferNotifier are told about the
+// new holder; a failed notification does not undo the transfer.
+type AcceptTicketTransferUseCase struct {
+	repo           domain.EventRepository
+	transferRepo   domain.TicketTransferRepository
+	partnerFactory service.PartnerFactory
+	notifyPartners bool
+}
+
+func NewAcceptTicketTransferUseCase(repo domain.EventRepository, transferRepo domain.TicketTransferRepository, partnerFactory service.PartnerFactory, notifyPartners bool) *AcceptTicketTransferUseCase {
+	return &AcceptTicketTransferUseCase{repo: repo, transferRepo: transferRepo, partnerFactory: partnerFactory, notifyPartners: notifyPartners}
+}
+
+func (uc *AcceptTicketTransferUseCase) Execute(input AcceptTicketTransferInputDTO) (*TicketDTO, error) {
+
+	// Buscando dados em db.
+	transfer, err := uc.transferRepo.FindTransferByTokenHash(domain.HashTransferToken(input.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, transfer.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transfer.Accept(input.Token, ticket, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrTicketTransferExpired) {
+			if err := uc.transferRepo.UpdateTransfer(transfer); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	if err := uc.transferRepo.AcceptTransfer(transfer, ticket); err != nil {
+		return nil, err
+	}
+
+	if uc.notifyPartners {
+		if err := uc.notifyPartner(ticket, transfer); err != nil {
+			log.Printf("notify partner of transfer %s: %v", transfer.Id, err)
+		}
+	}
+
+	ticketDTO := newTicketDTO(ticket)
+	return &ticketDTO, nil
+}
+
+func (uc *AcceptTicketTransferUseCase) notifyPartner(ticket *domain.Ticket, transfer *domain.TicketTransfer) error {
+	event, err := uc.repo.FindEventById(ticket.EventId)
+	if err != nil {
+		return err
+	}
+
+	partner, err := uc.partnerFactory.CreatePartner(event.PartnerId)
+	if err != nil {
+		return err
+	}
+
+	notifier, ok := partner.(service.TransferNotifier)
+	if !ok {
+		return nil
+	}
+
+	notification := &service.TransferNotification{
+		EventId:   event.Id,
+		FromEmail: transfer.FromEmail,
+		ToEmail:   transfer.ToEmail,
+	}
+	if ticket.Spot != nil {
+		notification.Spot = ticket.Spot.Name
+	}
+	return notifier.NotifyTransfer(notification)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+type AddVenueLayoutInputDTO struct {
+	OrganizationId string `json:"-"`
+	VenueId        string `json:"-"`
+	CreateVenueLayoutDTO
+}
+
+// AddVenueLayoutUseCase stores a new named layout in a venue, to be used
+// by the events created there later. Existing events keep their spots.
+type AddVenueLayoutUseCase struct {
+	venueRepo domain.VenueRepository
+}
+
+func NewAddVenueLayoutUseCase(venueRepo domain.VenueRepository) *AddVenueLayoutUseCase {
+	return &AddVenueLayoutUseCase{venueRepo: venueRepo}
+}
+
+func (uc *AddVenueLayoutUseCase) Execute(input AddVenueLayoutInputDTO) (*VenueLayoutDTO, error) {
+
+	// Buscando dados em db.
+	venue, err := uc.venueRepo.FindOrganizationVenue(input.OrganizationId, input.VenueId)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := venue.AddLayout(input.Name, input.SpotLayoutDTO.toDomain())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.venueRepo.CreateVenueLayout(venue.Id, layout); err != nil {
+		return nil, err
+	}
+
+	layoutDTO := newVenueLayoutDTO(layout)
+	return &layoutDTO, nil
+}
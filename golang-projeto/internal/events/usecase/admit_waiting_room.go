@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type AdmitWaitingRoomOutputDTO struct {
+	Admitted int `json:"admitted"`
+}
+
+// AdmitWaitingRoomUseCase is run periodically to let the next customers of
+// every active waiting room through, at the rate of each room.
+type AdmitWaitingRoomUseCase struct {
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewAdmitWaitingRoomUseCase(roomRepo domain.WaitingRoomRepository) *AdmitWaitingRoomUseCase {
+	return &AdmitWaitingRoomUseCase{roomRepo: roomRepo}
+}
+
+func (uc *AdmitWaitingRoomUseCase) Execute() (*AdmitWaitingRoomOutputDTO, error) {
+	rooms, err := uc.roomRepo.FindActiveWaitingRooms()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	output := &AdmitWaitingRoomOutputDTO{}
+	for _, room := range rooms {
+		due := room.AdmissionsDue(now)
+		if due == 0 {
+			continue
+		}
+		admitted, err := uc.roomRepo.AdmitWaitingRoomEntries(room, due, now)
+		if err != nil {
+			return nil, err
+		}
+		output.Admitted += admitted
+	}
+	return output, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type BestAvailableInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	Quantity       int    `json:"quantity"`
+	TicketType     string `json:"ticket_type"`
+	Zone           string `json:"zone"`
+}
+
+type BestAvailableUseCase struct {
+	repo                 domain.EventRepository
+	seatSelectionService *domain.SeatSelectionService
+	spotHub              domain.SpotAvailabilityHub
+	holdDuration         time.Duration
+}
+
+func NewBestAvailableUseCase(repo domain.EventRepository, seatSelectionService *domain.SeatSelectionService, spotHub domain.SpotAvailabilityHub, holdDuration time.Duration) *BestAvailableUseCase {
+	return &BestAvailableUseCase{repo: repo, seatSelectionService: seatSelectionService, spotHub: spotHub, holdDuration: holdDuration}
+}
+
+func (uc *BestAvailableUseCase) Execute(input BestAvailableInputDTO) (*HoldDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	spots, err := uc.repo.FindSpotsByEventId(input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := uc.seatSelectionService.BestAvailable(spots, input.Quantity, input.Zone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := domain.NewHold(event, selected, domain.TicketType(input.TicketType), uc.holdDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateHold(hold); err != nil {
+		return nil, err
+	}
+	uc.spotHub.Publish(event.Id, domain.SpotChanges(hold.Spots, domain.SpotChangeHeld)...)
+
+	return newHoldDTO(hold), nil
+}
+
+func newHoldDTO(hold *domain.Hold) *HoldDTO {
+	return &HoldDTO{
+		Id:         hold.Id,
+		EventId:    hold.EventId,
+		Spots:      hold.SpotNames(),
+		TicketType: string(hold.TicketType),
+		ExpiresAt:  hold.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
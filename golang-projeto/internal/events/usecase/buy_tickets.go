@@ -1,44 +1,391 @@
 package usecase
 
 import (
+	"errors"
+	"log"
+	"slices"
+	"time"
+
 	"github.com/daffc/imersao18/golang/internal/events/domain"
 	"github.com/daffc/imersao18/golang/internal/events/infra/service"
 )
 
 type BuyTicketsInputDTO struct {
-	EventId    string   `json:"event_id"`
-	Spots      []string `json:"spots"`
-	TicketType string   `json:"ticket_type"`
-	CardHash   string   `json:"card_hash"`
-	Email      string   `json:"email"`
+	OrganizationId string   `json:"-"`
+	EventId        string   `json:"event_id"`
+	Spots          []string `json:"spots"`
+	TicketType     string   `json:"ticket_type"`
+	CardHash       string   `json:"card_hash"`
+	Email          string   `json:"email"`
+	HoldId         string   `json:"hold_id"`
+	Quantity       int      `json:"quantity"`
+	// AccessCode unlocks the presale, when it is the open sales phase.
+	AccessCode string `json:"access_code"`
+	// Principal is the authenticated customer, whose email replaces Email.
+	// Their saved preferences fill TicketType and AcceptObstructedView.
+	Principal *domain.Principal `json:"-"`
+
+	AcceptObstructedView bool          `json:"accept_obstructed_view"`
+	Attendees            []AttendeeDTO `json:"attendees"`
+}
+
+// AttendeeDTO identifies who will use the ticket for Spot (left empty for
+// general admission events). BirthDate uses the "2006-01-02" layout.
+type AttendeeDTO struct {
+	Spot      string `json:"spot"`
+	Name      string `json:"name"`
+	BirthDate string `json:"birth_date"`
 }
 
 type BuyTicketsOutputDTO struct {
-	Tickets []TicketDTO `json:"tickets"`
+	OrderId       string      `json:"order_id"`
+	Total         float64     `json:"total"`
+	PaymentStatus string      `json:"payment_status"`
+	Tickets       []TicketDTO `json:"tickets"`
 }
 
 type BuyTicketsUseCase struct {
-	repo           domain.EventRepository
-	partnerFactory service.PartnerFactory
+	repo            domain.EventRepository
+	orderRepo       domain.OrderRepository
+	partnerFactory  service.PartnerFactory
+	paymentGateway  domain.PaymentGateway
+	ageRatingPolicy domain.AgeRatingPolicy
+	spotHub         domain.SpotAvailabilityHub
+	accounts        customerAccounts
+	outbox          notificationOutbox
 }
 
-func NewBuyTicketsUseCase(repo domain.EventRepository, partnerFactory service.PartnerFactory) *BuyTicketsUseCase {
-	return &BuyTicketsUseCase{repo: repo, partnerFactory: partnerFactory}
+func NewBuyTicketsUseCase(
+	repo domain.EventRepository,
+	orderRepo domain.OrderRepository,
+	partnerFactory service.PartnerFactory,
+	paymentGateway domain.PaymentGateway,
+	ageRatingPolicy domain.AgeRatingPolicy,
+	spotHub domain.SpotAvailabilityHub,
+	customerRepo domain.CustomerRepository,
+	notificationRepo domain.NotificationRepository,
+	notificationService *domain.NotificationService,
+) *BuyTicketsUseCase {
+	return &BuyTicketsUseCase{
+		repo:            repo,
+		orderRepo:       orderRepo,
+		partnerFactory:  partnerFactory,
+		paymentGateway:  paymentGateway,
+		ageRatingPolicy: ageRatingPolicy,
+		spotHub:         spotHub,
+		accounts:        customerAccounts{repo: customerRepo},
+		outbox:          notificationOutbox{repo: notificationRepo, service: notificationService},
+	}
 }
 
 func (uc *BuyTicketsUseCase) Execute(input BuyTicketsInputDTO) (*BuyTicketsOutputDTO, error) {
 
-	event, err := uc.repo.FindEventById(input.EventId)
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lugares retidos previamente (ex.: "best available") podem ser comprados
+	// apenas informando o hold. Holds de eventos sem lugares marcados guardam
+	// uma quantidade de ingressos.
+	var hold *domain.Hold
+	if input.HoldId != "" {
+		hold, err = uc.repo.FindHoldById(input.HoldId)
+		if err != nil {
+			return nil, err
+		}
+		if hold.EventId != event.Id {
+			return nil, domain.ErrHoldNotFound
+		}
+		if hold.IsExpired(time.Now()) {
+			return nil, domain.ErrHoldExpired
+		}
+		if len(input.Spots) == 0 {
+			input.Spots = hold.SpotNames()
+		}
+		if input.Quantity == 0 {
+			input.Quantity = hold.Quantity
+		}
+		if input.TicketType == "" {
+			input.TicketType = string(hold.TicketType)
+		}
+	}
+
+	// Compras autenticadas ficam na conta do cliente, cujas preferências
+	// completam o pedido.
+	var customer *domain.Customer
+	if input.Principal != nil {
+		customer, err = uc.accounts.resolve(input.Principal)
+		if err != nil {
+			return nil, err
+		}
+		if input.Email == "" {
+			input.Email = customer.PrimaryEmail()
+		}
+		if input.TicketType == "" {
+			input.TicketType = string(customer.Preferences.TicketType)
+		}
+		input.AcceptObstructedView = input.AcceptObstructedView || customer.Preferences.AcceptObstructedView
+	}
+
+	// Eventos sem lugares marcados são vendidos por quantidade.
+	quantity := len(input.Spots)
+	if event.IsGeneralAdmission() {
+		if len(input.Spots) > 0 {
+			return nil, domain.ErrEventGeneralAdmission
+		}
+		quantity = input.Quantity
+	} else if len(input.Spots) == 0 {
+		return nil, domain.ErrEventSeated
+	} else if hasDuplicates(input.Spots) {
+		// Um lugar repetido só falharia na reserva, depois do pagamento.
+		return nil, domain.ErrSpotDuplicated
+	}
+	if hold != nil && event.IsGeneralAdmission() {
+		err = event.CanSellHeld(quantity, hold)
+	} else {
+		err = event.CanSell(quantity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Na fase de vendas aberta valem o preço e a cota da fase.
+	phase, price, err := event.SellInSalesPhase(time.Now(), quantity, input.AccessCode)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsValidTicketType(domain.TicketType(input.TicketType)) {
+		return nil, domain.ErrInvalidTicketType
+	}
+
+	order, err := domain.NewOrder(event, input.Email, input.CardHash)
+	if err != nil {
+		return nil, err
+	}
+	if customer != nil {
+		order.PlaceFor(customer)
+	}
+	if hold != nil {
+		if err := hold.CanBeUsedBy(order.Email); err != nil {
+			return nil, err
+		}
+		order.HoldId = hold.Id
+	}
+
+	// Verificando disponibilidade e regras de elegibilidade antes de
+	// reservar junto ao parceiro.
+	spots := make([]*domain.Spot, len(input.Spots))
+	for i, spotName := range input.Spots {
+		spot, err := uc.repo.FindSpotByName(event.Id, spotName)
+		if err != nil {
+			return nil, err
+		}
+		if err := spot.CanBeReservedBy(input.HoldId); err != nil {
+			return nil, err
+		}
+		spots[i] = spot
+	}
+	if err := domain.ValidateSpotSelection(spots, input.AcceptObstructedView); err != nil {
+		return nil, err
+	}
+
+	// Verificando a classificação indicativa na data do evento.
+	attendees, err := uc.attendees(event, input, quantity)
 	if err != nil {
 		return nil, err
 	}
 
+	// Reservando os limites de compra por pedido, email, cartão e período, a
+	// capacidade do evento e a cota da fase de vendas; eles são devolvidos
+	// caso a compra falhe.
+	limits, err := uc.repo.FindPurchaseLimits(event.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.claim(event, phase, order, limits, hold, quantity); err != nil {
+		return nil, err
+	}
+
+	// Autorizando o pagamento antes de reservar junto ao parceiro; a
+	// autorização é cancelada (void) se qualquer etapa seguinte falhar.
+	// Pedidos gratuitos, como cortesias, não passam pelo gateway.
+	amount := domain.RoundAmount(float64(quantity) * domain.TicketPrice(price, domain.TicketType(input.TicketType)))
+	var paymentId string
+	if amount > 0 {
+		paymentId, err = uc.paymentGateway.Authorize(order.CardHash, amount, order.Id)
+		if err != nil {
+			uc.release(event, phase, order, hold, quantity)
+			return nil, err
+		}
+	}
+	order.Authorize(paymentId)
+
+	output, err := uc.reserve(event, phase, price, order, input, quantity, attendees)
+	if err != nil {
+		uc.void(order)
+		uc.abandon(event, phase, order, hold, quantity)
+		return nil, err
+	}
+
+	// Avisando quem acompanha o evento: os lugares foram vendidos ou, se a
+	// cobrança falhou, liberados novamente.
+	if err := uc.capture(order); err != nil {
+		uc.publishSpots(order, domain.SpotChangeReleased)
+		return nil, err
+	}
+	uc.publishSpots(order, domain.SpotChangeSold)
+	output.PaymentStatus = string(order.PaymentStatus)
+
+	// A compra já foi concluída; uma falha ao enfileirar a confirmação não
+	// deve ser reportada ao cliente como falha na compra.
+	data := &domain.NotificationData{Recipient: order.Email, Event: event, Order: order, Tickets: order.Tickets}
+	if err := uc.outbox.enqueue(domain.NotificationPurchaseConfirmation, "purchase_confirmation:"+order.Id, data); err != nil {
+		log.Printf("enqueue purchase confirmation for order %s: %v", order.Id, err)
+	}
+
+	return output, nil
+}
+
+// capture charges the order once its tickets are stored. If the charge
+// fails, the authorization is voided and the tickets are cancelled, which
+// also frees their spots and gives their places back to the event.
+func (uc *BuyTicketsUseCase) capture(order *domain.Order) error {
+	var err error
+	if order.PaymentId != "" {
+		err = uc.paymentGateway.Capture(order.PaymentId, order.Total)
+	}
+	if err == nil {
+		if err := order.Capture(); err != nil {
+			return err
+		}
+		return uc.orderRepo.UpdateOrderPayment(order)
+	}
+
+	uc.void(order)
+	order.Void()
+	if err := uc.orderRepo.UpdateOrderPayment(order); err != nil {
+		log.Printf("update payment of order %s: %v", order.Id, err)
+	}
+	for i := range order.Tickets {
+		ticket := &order.Tickets[i]
+		if ticket.Cancel() == nil {
+			if err := uc.repo.CancelTicket(ticket); err != nil {
+				log.Printf("cancel ticket %s of order %s: %v", ticket.Id, order.Id, err)
+			}
+		}
+	}
+	return err
+}
+
+// void releases the payment authorization of a purchase that failed. The
+// purchase error is what the customer gets, so failures are only logged.
+func (uc *BuyTicketsUseCase) void(order *domain.Order) {
+	if order.PaymentId == "" {
+		return
+	}
+	if err := uc.paymentGateway.Void(order.PaymentId); err != nil {
+		log.Printf("void payment %s of order %s: %v", order.PaymentId, order.Id, err)
+	}
+}
+
+// abandon undoes a purchase that failed before its order was stored. The
+// tickets already created are cancelled, which frees their spots and gives
+// their places back to the event and the sales phase; what claim took for
+// the tickets never created is released.
+func (uc *BuyTicketsUseCase) abandon(event *domain.Event, phase *domain.SalesPhase, order *domain.Order, hold *domain.Hold, quantity int) {
+	unused := quantity
+	for i := range order.Tickets {
+		ticket := &order.Tickets[i]
+		if ticket.Cancel() != nil {
+			continue
+		}
+		err := uc.repo.CancelTicket(ticket)
+		if errors.Is(err, domain.ErrTicketAlreadyCancelled) {
+			// O ingresso não chegou a ser gravado.
+			continue
+		}
+		if err != nil {
+			log.Printf("cancel ticket %s of order %s: %v", ticket.Id, order.Id, err)
+		}
+		unused--
+	}
+	uc.release(event, phase, order, hold, unused)
+}
+
+// claim takes the purchase limits, capacity and sales phase quota needed
+// by a purchase, giving back what it already took when one of them runs
+// out. Purchases of a general admission hold take the capacity the hold
+// already claimed.
+func (uc *BuyTicketsUseCase) claim(event *domain.Event, phase *domain.SalesPhase, order *domain.Order, limits *domain.PurchaseLimits, hold *domain.Hold, quantity int) error {
+	if err := uc.orderRepo.ClaimPurchase(limits, order, quantity); err != nil {
+		return err
+	}
+	if err := uc.claimCapacity(event, hold, quantity); err != nil {
+		uc.release(event, nil, order, hold, 0)
+		return err
+	}
+	if phase != nil {
+		if err := uc.repo.ClaimSalesPhaseTickets(event.Id, phase.Kind, quantity); err != nil {
+			uc.release(event, nil, order, hold, quantity)
+			return err
+		}
+	}
+	return nil
+}
+
+// release gives back what claim took for quantity tickets of a purchase
+// that failed.
+func (uc *BuyTicketsUseCase) release(event *domain.Event, phase *domain.SalesPhase, order *domain.Order, hold *domain.Hold, quantity int) {
+	if quantity > 0 {
+		uc.releaseCapacity(event, hold, quantity)
+		if phase != nil {
+			if err := uc.repo.ReleaseSalesPhaseTickets(event.Id, phase.Kind, quantity); err != nil {
+				log.Printf("release %d %s tickets of event %s: %v", quantity, phase.Kind, event.Id, err)
+			}
+		}
+	}
+	if err := uc.orderRepo.ReleasePurchase(order.Id); err != nil {
+		log.Printf("release purchase claim of order %s: %v", order.Id, err)
+	}
+}
+
+func (uc *BuyTicketsUseCase) claimCapacity(event *domain.Event, hold *domain.Hold, quantity int) error {
+	if hold != nil && event.IsGeneralAdmission() {
+		return uc.repo.ClaimHoldTickets(hold.Id, quantity)
+	}
+	return uc.repo.ClaimTickets(event.Id, quantity)
+}
+
+func (uc *BuyTicketsUseCase) releaseCapacity(event *domain.Event, hold *domain.Hold, quantity int) {
+	var err error
+	if hold != nil && event.IsGeneralAdmission() {
+		err = uc.repo.ReleaseHoldTickets(hold.Id, quantity)
+	} else {
+		err = uc.repo.ReleaseTickets(event.Id, quantity)
+	}
+	if err != nil {
+		log.Printf("release %d tickets of event %s: %v", quantity, event.Id, err)
+	}
+}
+
+func (uc *BuyTicketsUseCase) publishSpots(order *domain.Order, kind domain.SpotChangeKind) {
+	var spots []*domain.Spot
+	for _, ticket := range order.Tickets {
+		if ticket.Spot != nil {
+			spots = append(spots, ticket.Spot)
+		}
+	}
+	uc.spotHub.Publish(order.EventId, domain.SpotChanges(spots, kind)...)
+}
+
+func (uc *BuyTicketsUseCase) reserve(event *domain.Event, phase *domain.SalesPhase, price float64, order *domain.Order, input BuyTicketsInputDTO, quantity int, attendees []domain.Attendee) (*BuyTicketsOutputDTO, error) {
 	req := &service.ReservationRequest{
 		EventId:    input.EventId,
 		Spots:      input.Spots,
+		Quantity:   quantity,
 		TicketType: input.TicketType,
-		CardHash:   input.CardHash,
-		Email:      input.Email,
+		CardHash:   order.CardHash,
+		Email:      order.Email,
 	}
 
 	partnerSerice, err := uc.partnerFactory.CreatePartner(event.PartnerId)
@@ -60,17 +407,29 @@ func (uc *BuyTicketsUseCase) Execute(input BuyTicketsInputDTO) (*BuyTicketsOutpu
 
 	tickets := make([]domain.Ticket, len(reservationResponse))
 	for i, reservation := range reservationResponse {
-		// Recovering related spot
-		spot, err := uc.repo.FindSpotByName(reservation.EventId, reservation.Spot)
-		if err != nil {
-			return nil, err
+		// Recovering related spot (none for general admission)
+		var spot *domain.Spot
+		attendeeIndex := i
+		if !event.IsGeneralAdmission() {
+			spot, err = uc.repo.FindSpotByName(reservation.EventId, reservation.Spot)
+			if err != nil {
+				return nil, err
+			}
+			attendeeIndex = slices.Index(input.Spots, spot.Name)
 		}
 
 		// Generating a new ticket
-		ticket, err := domain.NewTicket(event, spot, domain.TicketType(input.TicketType))
+		ticket, err := domain.NewTicket(event, spot, domain.TicketType(input.TicketType), price)
 		if err != nil {
 			return nil, err
 		}
+		if attendeeIndex >= 0 && attendeeIndex < len(attendees) {
+			ticket.Attendee = attendees[attendeeIndex]
+		}
+		if phase != nil {
+			ticket.SalesPhase = phase.Kind
+		}
+		order.AddTicket(ticket)
 
 		// Creating ticket (database)
 		err = uc.repo.CreateTicket(ticket)
@@ -79,34 +438,87 @@ func (uc *BuyTicketsUseCase) Execute(input BuyTicketsInputDTO) (*BuyTicketsOutpu
 		}
 
 		// Reserving spot
-		err = spot.Reserve(spot.TicketId)
-		if err != nil {
-			return nil, err
-		}
+		if spot != nil {
+			err = spot.Reserve(ticket.Id, input.HoldId)
+			if err != nil {
+				return nil, err
+			}
 
-		err = uc.repo.ReserveSpot(spot.Id, ticket.Id)
-		if err != nil {
-			return nil, err
+			err = uc.repo.ReserveSpot(spot, input.HoldId)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		tickets[i] = domain.Ticket{
-			Id:         ticket.Id,
-			Spot:       ticket.Spot,
-			TicketType: ticket.TicketType,
-			Price:      ticket.Price,
+			Id:          ticket.Id,
+			Spot:        ticket.Spot,
+			TicketType:  ticket.TicketType,
+			Status:      ticket.Status,
+			Price:       ticket.Price,
+			Attendee:    ticket.Attendee,
+			HolderEmail: ticket.HolderEmail,
+			SalesPhase:  ticket.SalesPhase,
 		}
 	}
 
+	// Registrando o pedido, usado como histórico para os limites de compra.
+	if err := uc.orderRepo.CreateOrder(order); err != nil {
+		return nil, err
+	}
+
 	ticketsDTO := make([]TicketDTO, len(tickets))
 	for i, ticket := range tickets {
-		ticketsDTO[i] = TicketDTO{
-			Id:         ticket.Id,
-			SpotId:     ticket.Spot.Id,
-			TicketType: string(ticket.TicketType),
-			Price:      ticket.Price,
+		ticketsDTO[i] = newTicketDTO(&ticket)
+	}
+
+	return &BuyTicketsOutputDTO{OrderId: order.Id, Total: order.Total, Tickets: ticketsDTO}, nil
+
+}
+
+// attendees parses the attendees of the order and validates them against
+// the event rating. For seated events they are matched to input.Spots by
+// spot name; for general admission they are taken in order. Attendees are
+// optional for events rated RatingLivre and required for every ticket
+// otherwise.
+func (uc *BuyTicketsUseCase) attendees(event *domain.Event, input BuyTicketsInputDTO, quantity int) ([]domain.Attendee, error) {
+	if event.Rating == domain.RatingLivre && len(input.Attendees) == 0 {
+		return nil, nil
+	}
+	if len(input.Attendees) != quantity {
+		return nil, domain.ErrAttendeeRequired
+	}
+
+	attendees := make([]domain.Attendee, quantity)
+	for i, attendeeDTO := range input.Attendees {
+		index := i
+		if !event.IsGeneralAdmission() {
+			index = slices.Index(input.Spots, attendeeDTO.Spot)
+			if index == -1 {
+				return nil, domain.ErrAttendeeRequired
+			}
 		}
+
+		attendee := domain.Attendee{Name: attendeeDTO.Name}
+		if attendeeDTO.BirthDate != "" {
+			birthDate, err := time.Parse("2006-01-02", attendeeDTO.BirthDate)
+			if err != nil {
+				return nil, domain.ErrAttendeeInvalidBirthDate
+			}
+			attendee.BirthDate = birthDate
+		}
+		attendees[index] = attendee
 	}
 
-	return &BuyTicketsOutputDTO{Tickets: ticketsDTO}, nil
+	if err := uc.ageRatingPolicy.ValidateAttendees(event, attendees); err != nil {
+		return nil, err
+	}
+	return attendees, nil
+}
 
+// hasDuplicates tells whether a name is listed more than once.
+func hasDuplicates(names []string) bool {
+	sorted := slices.Clone(names)
+	slices.Sort(sorted)
+	return len(slices.Compact(sorted)) != len(names)
 }
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"log"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type CancelEventInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	Reason         string `json:"reason"`
+}
+
+type CancelEventOutputDTO struct {
+	EventId          string `json:"event_id"`
+	Status           string `json:"status"`
+	CancelledTickets int    `json:"cancelled_tickets"`
+}
+
+// CancelEventUseCase calls an event off, cancelling all its tickets,
+// refunding them and notifying their holders. Recurring events are
+// cancelled with all their sessions.
+type CancelEventUseCase struct {
+	repo    domain.EventRepository
+	refunds paymentRefunds
+	outbox  notificationOutbox
+}
+
+func NewCancelEventUseCase(repo domain.EventRepository, orderRepo domain.OrderRepository, paymentGateway domain.PaymentGateway, notificationRepo domain.NotificationRepository, notificationService *domain.NotificationService) *CancelEventUseCase {
+	return &CancelEventUseCase{
+		repo:    repo,
+		refunds: paymentRefunds{orderRepo: orderRepo, gateway: paymentGateway},
+		outbox:  notificationOutbox{repo: notificationRepo, service: notificationService},
+	}
+}
+
+func (uc *CancelEventUseCase) Execute(input CancelEventInputDTO) (*CancelEventOutputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsCancelled() {
+		return nil, domain.ErrEventCancelled
+	}
+
+	// Cancelar um evento recorrente cancela também as sessões ainda ativas.
+	var cancelledTickets int
+	if event.HasSessions() {
+		sessions, err := uc.repo.FindEventSessions(event.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if session.IsCancelled() {
+				continue
+			}
+			cancelled, err := uc.cancel(session, input.Reason)
+			if err != nil {
+				return nil, err
+			}
+			cancelledTickets += cancelled
+		}
+	}
+
+	cancelled, err := uc.cancel(event, input.Reason)
+	if err != nil {
+		return nil, err
+	}
+	cancelledTickets += cancelled
+
+	return &CancelEventOutputDTO{
+		EventId:          event.Id,
+		Status:           string(event.Status),
+		CancelledTickets: cancelledTickets,
+	}, nil
+}
+
+// cancel calls a single event off and returns how many tickets it had.
+func (uc *CancelEventUseCase) cancel(event *domain.Event, reason string) (int, error) {
+	if err := event.Cancel(); err != nil {
+		return 0, err
+	}
+
+	// Os ingressos são lidos antes do cancelamento para saber quem avisar.
+	tickets, err := uc.repo.FindActiveTicketsByEventId(event.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := uc.repo.CancelEvent(event); err != nil {
+		return 0, err
+	}
+	uc.refunds.refund(tickets)
+
+	// O evento já foi cancelado; uma falha ao enfileirar os avisos não
+	// desfaz o cancelamento.
+	if err := uc.outbox.enqueueForHolders(domain.NotificationEventCancelled, "event_cancelled:"+event.Id, event, tickets, reason); err != nil {
+		log.Printf("enqueue event cancelled notifications for event %s: %v", event.Id, err)
+	}
+
+	return len(tickets), nil
+}
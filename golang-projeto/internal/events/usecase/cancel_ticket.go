@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"log"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type CancelTicketInputDTO struct {
+	OrganizationId string
+	TicketId       string
+	Principal      *domain.Principal
+}
+
+type CancelTicketUseCase struct {
+	repo               domain.EventRepository
+	offerWaitlistSpots *OfferWaitlistSpotsUseCase
+	spotHub            domain.SpotAvailabilityHub
+	refunds            paymentRefunds
+	outbox             notificationOutbox
+}
+
+func NewCancelTicketUseCase(repo domain.EventRepository, orderRepo domain.OrderRepository, paymentGateway domain.PaymentGateway, offerWaitlistSpots *OfferWaitlistSpotsUseCase, spotHub domain.SpotAvailabilityHub, notificationRepo domain.NotificationRepository, notificationService *domain.NotificationService) *CancelTicketUseCase {
+	return &CancelTicketUseCase{
+		repo:               repo,
+		offerWaitlistSpots: offerWaitlistSpots,
+		spotHub:            spotHub,
+		refunds:            paymentRefunds{orderRepo: orderRepo, gateway: paymentGateway},
+		outbox:             notificationOutbox{repo: notificationRepo, service: notificationService},
+	}
+}
+
+func (uc *CancelTicketUseCase) Execute(input CancelTicketInputDTO) (*TicketDTO, error) {
+
+	// Buscando dados em db.
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, input.TicketId)
+	if err != nil {
+		return nil, err
+	}
+	if !input.Principal.CanAccessTicket(ticket) {
+		return nil, domain.ErrForbidden
+	}
+
+	if err := ticket.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CancelTicket(ticket); err != nil {
+		return nil, err
+	}
+	if ticket.Spot != nil {
+		uc.spotHub.Publish(ticket.EventId, domain.NewSpotChange(ticket.Spot, domain.SpotChangeReleased))
+	}
+	uc.refunds.refund([]domain.Ticket{*ticket})
+
+	// O cancelamento já foi concluído; falhas ao avisar o titular são apenas
+	// registradas.
+	if event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, ticket.EventId); err != nil {
+		log.Printf("enqueue ticket cancelled notification for ticket %s: %v", ticket.Id, err)
+	} else {
+		data := &domain.NotificationData{Recipient: ticket.HolderEmail, Event: event, Tickets: []domain.Ticket{*ticket}}
+		if err := uc.outbox.enqueue(domain.NotificationTicketCancelled, "ticket_cancelled:"+ticket.Id, data); err != nil {
+			log.Printf("enqueue ticket cancelled notification for ticket %s: %v", ticket.Id, err)
+		}
+	}
+
+	// O lugar liberado é oferecido à lista de espera.
+	if _, err := uc.offerWaitlistSpots.Execute(OfferWaitlistSpotsInputDTO{EventId: ticket.EventId}); err != nil {
+		return nil, err
+	}
+
+	ticketDTO := newTicketDTO(ticket)
+	return &ticketDTO, nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// CheckInTicketInputDTO carries a scanned credential. ScannedAt is only set
+// by gate devices syncing scans they made while offline; live scans use the
+// current time.
+type CheckInTicketInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	GateId         string `json:"gate_id"`
+	Credential     string `json:"credential"`
+	ScannedAt      string `json:"scanned_at"`
+}
+
+type CheckInDTO struct {
+	TicketId     string `json:"ticket_id"`
+	EventId      string `json:"event_id"`
+	Spot         string `json:"spot"`
+	TicketType   string `json:"ticket_type"`
+	AttendeeName string `json:"attendee_name,omitempty"`
+	GateId       string `json:"gate_id"`
+	UsedAt       string `json:"used_at"`
+}
+
+type CheckInTicketUseCase struct {
+	repo           domain.EventRepository
+	signer         domain.CredentialSigner
+	checkInService *domain.CheckInService
+}
+
+func NewCheckInTicketUseCase(repo domain.EventRepository, signer domain.CredentialSigner, checkInService *domain.CheckInService) *CheckInTicketUseCase {
+	return &CheckInTicketUseCase{repo: repo, signer: signer, checkInService: checkInService}
+}
+
+func (uc *CheckInTicketUseCase) Execute(input CheckInTicketInputDTO) (*CheckInDTO, error) {
+	scannedAt := time.Now()
+	if input.ScannedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, input.ScannedAt)
+		if err != nil {
+			return nil, domain.ErrCheckInInvalidScannedAt
+		}
+		scannedAt = parsed
+	}
+
+	// A assinatura é conferida antes de qualquer acesso ao db.
+	credential, err := uc.signer.Verify(input.Credential)
+	if err != nil {
+		return nil, err
+	}
+	if credential.EventId != input.EventId {
+		return nil, domain.ErrCheckInWrongEvent
+	}
+
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, credential.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkInService.CheckIn(credential, ticket, input.EventId, input.GateId, scannedAt); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CheckInTicket(ticket); err != nil {
+		return nil, err
+	}
+
+	return &CheckInDTO{
+		TicketId:     ticket.Id,
+		EventId:      ticket.EventId,
+		Spot:         credential.Spot,
+		TicketType:   string(ticket.TicketType),
+		AttendeeName: ticket.Attendee.Name,
+		GateId:       ticket.GateId,
+		UsedAt:       ticket.UsedAt.UTC().Format(time.RFC3339),
+	}, nil
+}
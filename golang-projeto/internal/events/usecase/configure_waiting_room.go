@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ConfigureWaitingRoomInputDTO struct {
+	OrganizationId      string `json:"-"`
+	EventId             string `json:"event_id"`
+	Active              bool   `json:"active"`
+	AdmissionRate       int    `json:"admission_rate"`
+	AdmissionTTLSeconds int    `json:"admission_ttl_seconds"`
+}
+
+// ConfigureWaitingRoomUseCase opens, tunes or closes the waiting room of an
+// event. Closing it lets everyone through to checkout again.
+type ConfigureWaitingRoomUseCase struct {
+	repo     domain.EventRepository
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewConfigureWaitingRoomUseCase(repo domain.EventRepository, roomRepo domain.WaitingRoomRepository) *ConfigureWaitingRoomUseCase {
+	return &ConfigureWaitingRoomUseCase{repo: repo, roomRepo: roomRepo}
+}
+
+func (uc *ConfigureWaitingRoomUseCase) Execute(input ConfigureWaitingRoomInputDTO) (*WaitingRoomDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := domain.NewWaitingRoom(event, input.Active, input.AdmissionRate, time.Duration(input.AdmissionTTLSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.roomRepo.SaveWaitingRoom(room); err != nil {
+		return nil, err
+	}
+
+	waiting, err := uc.roomRepo.CountWaiting(event.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newWaitingRoomDTO(room, waiting), nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// eventLocalDateLayout is how event dates are given in the venue time zone.
+const eventLocalDateLayout = "2006-01-02T15:04"
+
+// CreateEventInputDTO schedules an event at a venue. With Layout, the event
+// is seated and gets one spot per seat of that venue layout; without it,
+// the event is general admission for Capacity tickets, or for the whole
+// venue when Capacity is zero. Date is either local to the venue, like
+// "2025-12-31T21:00", or RFC 3339 with an offset.
+//
+// With Recurrence, Date is the first session and the event gets one
+// session per occurrence, each with its own spots and capacity. Sales open
+// at SalesStartAt, or right away, and close SalesCloseMinutes before each
+// session starts.
+type CreateEventInputDTO struct {
+	OrganizationId string  `json:"-"`
+	VenueId        string  `json:"venue_id"`
+	Layout         string  `json:"layout"`
+	Capacity       int     `json:"capacity"`
+	Name           string  `json:"name"`
+	Rating         string  `json:"rating"`
+	Date           string  `json:"date"`
+	ImageURL       string  `json:"image_url"`
+	Price          float64 `json:"price"`
+	PartnerId      int     `json:"partner_id"`
+
+	SalesStartAt      string         `json:"sales_start_at"`
+	SalesCloseMinutes int            `json:"sales_close_minutes"`
+	Recurrence        *RecurrenceDTO `json:"recurrence"`
+}
+
+type CreateEventUseCase struct {
+	repo             domain.EventRepository
+	organizationRepo domain.OrganizationRepository
+	venueRepo        domain.VenueRepository
+	spotService      *domain.SpotService
+}
+
+func NewCreateEventUseCase(repo domain.EventRepository, organizationRepo domain.OrganizationRepository, venueRepo domain.VenueRepository, spotService *domain.SpotService) *CreateEventUseCase {
+	return &CreateEventUseCase{repo: repo, organizationRepo: organizationRepo, venueRepo: venueRepo, spotService: spotService}
+}
+
+func (uc *CreateEventUseCase) Execute(input CreateEventInputDTO) (*EventDTO, error) {
+
+	// Buscando dados em db.
+	organization, err := uc.organizationRepo.FindOrganizationById(input.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	venue, err := uc.venueRepo.FindOrganizationVenue(organization.Id, input.VenueId)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := venue.Location()
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseEventDate(input.Date, location)
+	if err != nil {
+		return nil, err
+	}
+	event, err := domain.NewEvent(organization, venue, input.Name, domain.Rating(input.Rating), date, input.ImageURL, input.Price, input.PartnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var salesStartAt time.Time
+	if input.SalesStartAt != "" {
+		if salesStartAt, err = parseEventDate(input.SalesStartAt, location); err != nil {
+			return nil, err
+		}
+	}
+	if err := event.OpenSales(salesStartAt, time.Duration(input.SalesCloseMinutes)*time.Minute); err != nil {
+		return nil, err
+	}
+
+	var layout *domain.VenueLayout
+	if input.Layout != "" {
+		if layout, err = venue.Layout(input.Layout); err != nil {
+			return nil, err
+		}
+		if err := event.Seat(layout); err != nil {
+			return nil, err
+		}
+	} else if input.Capacity > 0 {
+		if err := event.LimitCapacity(input.Capacity, venue); err != nil {
+			return nil, err
+		}
+	}
+
+	// Eventos recorrentes vendem ingressos por sessão; o evento pai não
+	// tem lugares próprios.
+	sessions := []*domain.Event{event}
+	if input.Recurrence != nil {
+		rule, err := input.Recurrence.toDomain(location)
+		if err != nil {
+			return nil, err
+		}
+		if sessions, err = event.ScheduleSessions(rule, location); err != nil {
+			return nil, err
+		}
+	}
+
+	// Eventos com lugares marcados recebem os lugares do layout do local.
+	if layout != nil {
+		for _, session := range sessions {
+			if _, err := uc.spotService.GenerateSpots(session, layout.Layout); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := uc.repo.CreateEvent(event); err != nil {
+		return nil, err
+	}
+
+	eventDTO := newEventDTO(event)
+	return &eventDTO, nil
+}
+
+// parseEventDate reads a date local to the event time zone or with an
+// explicit offset.
+func parseEventDate(value string, location *time.Location) (time.Time, error) {
+	if date, err := time.ParseInLocation(eventLocalDateLayout, value, location); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, domain.ErrEventInvalidDateFormat
+	}
+	return date, nil
+}
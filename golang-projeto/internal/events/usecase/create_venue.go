@@ -0,0 +1,55 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+type CreateVenueInputDTO struct {
+	OrganizationId string                 `json:"-"`
+	Name           string                 `json:"name"`
+	Address        AddressDTO             `json:"address"`
+	Latitude       float64                `json:"latitude"`
+	Longitude      float64                `json:"longitude"`
+	Timezone       string                 `json:"timezone"`
+	Capacity       int                    `json:"capacity"`
+	Layouts        []CreateVenueLayoutDTO `json:"layouts"`
+}
+
+type CreateVenueLayoutDTO struct {
+	Name string `json:"name"`
+	SpotLayoutDTO
+}
+
+type CreateVenueUseCase struct {
+	organizationRepo domain.OrganizationRepository
+	venueRepo        domain.VenueRepository
+}
+
+func NewCreateVenueUseCase(organizationRepo domain.OrganizationRepository, venueRepo domain.VenueRepository) *CreateVenueUseCase {
+	return &CreateVenueUseCase{organizationRepo: organizationRepo, venueRepo: venueRepo}
+}
+
+func (uc *CreateVenueUseCase) Execute(input CreateVenueInputDTO) (*VenueDTO, error) {
+
+	// Buscando dados em db.
+	organization, err := uc.organizationRepo.FindOrganizationById(input.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err := domain.NewVenue(organization, input.Name, input.Address.toDomain(), input.Latitude, input.Longitude, input.Timezone, input.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	for _, layout := range input.Layouts {
+		if _, err := venue.AddLayout(layout.Name, layout.SpotLayoutDTO.toDomain()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.venueRepo.CreateVenue(venue); err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	venueDTO := newVenueDTO(venue)
+	return &venueDTO, nil
+}
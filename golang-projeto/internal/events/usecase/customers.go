@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type CustomerPreferencesDTO struct {
+	TicketType           string `json:"ticket_type"`
+	Zone                 string `json:"zone"`
+	AcceptObstructedView bool   `json:"accept_obstructed_view"`
+	MarketingEmails      bool   `json:"marketing_emails"`
+}
+
+type CustomerDTO struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Email       string                 `json:"email"`
+	Emails      []string               `json:"emails"`
+	Preferences CustomerPreferencesDTO `json:"preferences"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+// CustomerEventDTO are the event details listed with the tickets and orders
+// of a customer.
+type CustomerEventDTO struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Location     string `json:"location"`
+	Organization string `json:"organization"`
+	Date         string `json:"date"`
+	LocalDate    string `json:"local_date"`
+	Timezone     string `json:"timezone"`
+	ImageURL     string `json:"image_url"`
+	Status       string `json:"status"`
+}
+
+func newCustomerDTO(customer *domain.Customer) *CustomerDTO {
+	return &CustomerDTO{
+		Id:     customer.Id,
+		Name:   customer.Name,
+		Email:  customer.PrimaryEmail(),
+		Emails: customer.Emails,
+		Preferences: CustomerPreferencesDTO{
+			TicketType:           string(customer.Preferences.TicketType),
+			Zone:                 customer.Preferences.Zone,
+			AcceptObstructedView: customer.Preferences.AcceptObstructedView,
+			MarketingEmails:      customer.Preferences.MarketingEmails,
+		},
+		CreatedAt: customer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func newCustomerEventDTO(event *domain.Event) CustomerEventDTO {
+	return CustomerEventDTO{
+		Id:           event.Id,
+		Name:         event.Name,
+		Location:     event.Location,
+		Organization: event.Organization,
+		Date:         event.Date.UTC().Format(time.RFC3339),
+		LocalDate:    event.LocalDate().Format(time.RFC3339),
+		Timezone:     event.TimeLocation().String(),
+		ImageURL:     event.ImageURL,
+		Status:       string(event.Status),
+	}
+}
+
+// customerAccounts finds the account of an authenticated user, opening it
+// on their first request and linking every new verified email they sign in
+// with.
+type customerAccounts struct {
+	repo domain.CustomerRepository
+}
+
+func (a customerAccounts) resolve(principal *domain.Principal) (*domain.Customer, error) {
+	if principal == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	customer, err := a.repo.FindCustomerByUserId(principal.UserId)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		customer, err = domain.NewCustomer(principal)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.repo.CreateCustomer(customer); err != nil {
+			// Outra requisição do mesmo usuário pode ter criado a conta.
+			if existing, findErr := a.repo.FindCustomerByUserId(principal.UserId); findErr == nil {
+				return existing, nil
+			}
+			return nil, err
+		}
+		return customer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if customer.AddEmail(principal.Email) {
+		if err := a.repo.UpdateCustomer(customer); err != nil {
+			return nil, err
+		}
+	}
+	return customer, nil
+}
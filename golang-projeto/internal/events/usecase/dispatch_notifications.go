@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type DispatchNotificationsOutputDTO struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+// DispatchNotificationsUseCase is run periodically to send the due
+// notifications of the outbox, retrying failed deliveries with an
+// exponential backoff.
+type DispatchNotificationsUseCase struct {
+	notificationRepo domain.NotificationRepository
+	sender           domain.EmailSender
+	batchSize        int
+	maxAttempts      int
+	retryDelay       time.Duration
+}
+
+func NewDispatchNotificationsUseCase(notificationRepo domain.NotificationRepository, sender domain.EmailSender, batchSize, maxAttempts int, retryDelay time.Duration) *DispatchNotificationsUseCase {
+	return &DispatchNotificationsUseCase{
+		notificationRepo: notificationRepo,
+		sender:           sender,
+		batchSize:        batchSize,
+		maxAttempts:      maxAttempts,
+		retryDelay:       retryDelay,
+	}
+}
+
+func (uc *DispatchNotificationsUseCase) Execute() (*DispatchNotificationsOutputDTO, error) {
+	notifications, err := uc.notificationRepo.FindDueNotifications(time.Now(), uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &DispatchNotificationsOutputDTO{}
+	for _, notification := range notifications {
+		if err := uc.sender.Send(notification.Recipient, notification.Subject, notification.Body); err != nil {
+			notification.MarkFailed(err, time.Now(), uc.maxAttempts, uc.retryDelay)
+			output.Failed++
+		} else {
+			notification.MarkSent(time.Now())
+			output.Sent++
+		}
+		if err := uc.notificationRepo.UpdateNotification(notification); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type DispatchWebhookDeliveriesOutputDTO struct {
+	Delivered    int `json:"delivered"`
+	Failed       int `json:"failed"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+// DispatchWebhookDeliveriesUseCase is run periodically to send the due
+// webhook deliveries, signed with the secret of their subscription.
+// Failures are retried with an exponential backoff and dead-lettered after
+// maxAttempts.
+type DispatchWebhookDeliveriesUseCase struct {
+	webhookRepo domain.WebhookRepository
+	sender      domain.WebhookSender
+	batchSize   int
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+func NewDispatchWebhookDeliveriesUseCase(webhookRepo domain.WebhookRepository, sender domain.WebhookSender, batchSize, maxAttempts int, retryDelay time.Duration) *DispatchWebhookDeliveriesUseCase {
+	return &DispatchWebhookDeliveriesUseCase{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+	}
+}
+
+func (uc *DispatchWebhookDeliveriesUseCase) Execute() (*DispatchWebhookDeliveriesOutputDTO, error) {
+	deliveries, err := uc.webhookRepo.FindDueDeliveries(time.Now(), uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &DispatchWebhookDeliveriesOutputDTO{}
+	subscriptions := make(map[string]*domain.WebhookSubscription)
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		if !ok {
+			subscription, err = uc.webhookRepo.FindSubscriptionById(delivery.SubscriptionId)
+			if err != nil {
+				return nil, err
+			}
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+
+		uc.send(subscription, delivery)
+		switch delivery.Status {
+		case domain.WebhookDeliveryDelivered:
+			output.Delivered++
+		case domain.WebhookDeliveryDead:
+			output.DeadLettered++
+		default:
+			output.Failed++
+		}
+
+		if err := uc.webhookRepo.UpdateDelivery(delivery); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+func (uc *DispatchWebhookDeliveriesUseCase) send(subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	now := time.Now()
+	if !subscription.Active {
+		delivery.MarkFailed(0, errors.New("webhook subscription is inactive"), now, 1, uc.retryDelay)
+		return
+	}
+
+	headers := map[string]string{
+		"X-Webhook-Id":        delivery.Id,
+		"X-Webhook-Event":     delivery.EventName,
+		"X-Webhook-Signature": domain.SignWebhook(subscription.Secret, now, delivery.Body),
+	}
+	statusCode, err := uc.sender.Send(subscription.URL, headers, delivery.Body)
+	if err != nil {
+		delivery.MarkFailed(statusCode, err, time.Now(), uc.maxAttempts, uc.retryDelay)
+		return
+	}
+	delivery.MarkDelivered(statusCode, time.Now())
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// EnqueueWebhookDeliveriesUseCase fans a published domain event out to the
+// webhook subscriptions of the event's organization accepting it. It runs as a subscriber of the domain
+// event bus and may see the same event twice, which the repository ignores.
+type EnqueueWebhookDeliveriesUseCase struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewEnqueueWebhookDeliveriesUseCase(webhookRepo domain.WebhookRepository) *EnqueueWebhookDeliveriesUseCase {
+	return &EnqueueWebhookDeliveriesUseCase{webhookRepo: webhookRepo}
+}
+
+func (uc *EnqueueWebhookDeliveriesUseCase) Execute(event domain.DomainEvent) error {
+	subscriptions, err := uc.webhookRepo.FindEventSubscriptions(event.EventId)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*domain.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.Name) {
+			continue
+		}
+		delivery, err := domain.NewWebhookDelivery(subscription, event)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return uc.webhookRepo.CreateDeliveries(deliveries)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ExpireHoldsOutputDTO struct {
+	ReleasedHolds int `json:"released_holds"`
+	ClosedOffers  int `json:"closed_offers"`
+}
+
+// ExpireHoldsUseCase is run periodically to close expired waitlist offers,
+// release the spots of expired holds and offer them to the next customers
+// on the waitlist.
+type ExpireHoldsUseCase struct {
+	repo               domain.EventRepository
+	orderRepo          domain.OrderRepository
+	waitlistRepo       domain.WaitlistRepository
+	offerWaitlistSpots *OfferWaitlistSpotsUseCase
+	spotHub            domain.SpotAvailabilityHub
+}
+
+func NewExpireHoldsUseCase(repo domain.EventRepository, orderRepo domain.OrderRepository, waitlistRepo domain.WaitlistRepository, offerWaitlistSpots *OfferWaitlistSpotsUseCase, spotHub domain.SpotAvailabilityHub) *ExpireHoldsUseCase {
+	return &ExpireHoldsUseCase{repo: repo, orderRepo: orderRepo, waitlistRepo: waitlistRepo, offerWaitlistSpots: offerWaitlistSpots, spotHub: spotHub}
+}
+
+func (uc *ExpireHoldsUseCase) Execute() (*ExpireHoldsOutputDTO, error) {
+	now := time.Now()
+	output := &ExpireHoldsOutputDTO{}
+	events := make(map[string]bool)
+
+	// A oferta só é atendida se o próprio cliente da fila pagou um pedido
+	// com o hold ofertado.
+	offers, err := uc.waitlistRepo.FindExpiredWaitlistOffers(now)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range offers {
+		bought := false
+		if entry.HoldId != "" {
+			bought, err = uc.orderRepo.HasPaidHoldOrder(entry.HoldId, entry.Email)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := entry.Close(bought); err != nil {
+			return nil, err
+		}
+		if err := uc.waitlistRepo.UpdateWaitlistEntry(entry); err != nil {
+			return nil, err
+		}
+		events[entry.EventId] = true
+		output.ClosedOffers++
+	}
+
+	holds, err := uc.repo.FindExpiredHolds(now)
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		// Carregando os lugares ainda retidos para avisar quem acompanha o
+		// evento.
+		held, err := uc.repo.FindHoldById(hold.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.repo.ReleaseHold(hold.Id); err != nil {
+			return nil, err
+		}
+		for _, spot := range held.Spots {
+			spot.Release()
+		}
+		uc.spotHub.Publish(hold.EventId, domain.SpotChanges(held.Spots, domain.SpotChangeReleased)...)
+		events[hold.EventId] = true
+		output.ReleasedHolds++
+	}
+
+	for eventId := range events {
+		if _, err := uc.offerWaitlistSpots.Execute(OfferWaitlistSpotsInputDTO{EventId: eventId}); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ExpireTicketTransfersOutputDTO struct {
+	Expired int `json:"expired"`
+}
+
+type ExpireTicketTransfersUseCase struct {
+	transferRepo domain.TicketTransferRepository
+}
+
+func NewExpireTicketTransfersUseCase(transferRepo domain.TicketTransferRepository) *ExpireTicketTransfersUseCase {
+	return &ExpireTicketTransfersUseCase{transferRepo: transferRepo}
+}
+
+func (uc *ExpireTicketTransfersUseCase) Execute() (*ExpireTicketTransfersOutputDTO, error) {
+	expired, err := uc.transferRepo.ExpireTransfers(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &ExpireTicketTransfersOutputDTO{Expired: expired}, nil
+}
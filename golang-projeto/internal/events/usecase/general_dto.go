@@ -1,29 +1,137 @@
 package usecase
 
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// EventDTO carries Date in UTC and LocalDate with the offset of the event
+// Timezone, the IANA time zone of its venue. SalesPhase is the phase open
+// now and NextSalesPhase the next one to start, if any.
 type EventDTO struct {
-	Id           string  `json:"id"`
-	Name         string  `json:"name"`
-	Location     string  `json:"location"`
-	Organization string  `json:"organization"`
-	Rating       string  `json:"rating"`
-	Date         string  `json:"date"`
-	ImageURL     string  `json:"image_url"`
-	Capacity     int     `json:"capacity"`
-	Price        float64 `json:"price"`
-	PartnerId    int     `json:"partner_id"`
+	Id           string         `json:"id"`
+	ParentId     string         `json:"parent_id,omitempty"`
+	Name         string         `json:"name"`
+	Location     string         `json:"location"`
+	VenueId      string         `json:"venue_id,omitempty"`
+	Organization string         `json:"organization"`
+	Rating       string         `json:"rating"`
+	Date         string         `json:"date"`
+	LocalDate    string         `json:"local_date"`
+	Timezone     string         `json:"timezone"`
+	ImageURL     string         `json:"image_url"`
+	Capacity     int            `json:"capacity"`
+	Price        float64        `json:"price"`
+	PartnerId    int            `json:"partner_id"`
+	SeatingMode  string         `json:"seating_mode"`
+	Status       string         `json:"status"`
+	SalesStartAt string         `json:"sales_start_at,omitempty"`
+	SalesEndAt   string         `json:"sales_end_at,omitempty"`
+	Recurrence   *RecurrenceDTO `json:"recurrence,omitempty"`
+	Sessions     []EventDTO     `json:"sessions,omitempty"`
+
+	SalesPhase     *SalesPhaseDTO `json:"sales_phase,omitempty"`
+	NextSalesPhase *SalesPhaseDTO `json:"next_sales_phase,omitempty"`
 }
 
 type SpotDTO struct {
-	Id       string `json:"id"`
-	EventId  string `json:"event_id"`
-	Name     string `json:"name"`
-	Status   string `json:"status"`
-	TicketId string `json:"ticket_id"`
+	Id         string   `json:"id"`
+	EventId    string   `json:"event_id"`
+	Name       string   `json:"name"`
+	Zone       string   `json:"zone"`
+	Attributes []string `json:"attributes"`
+	Status     string   `json:"status"`
+	TicketId   string   `json:"ticket_id"`
 }
 
 type TicketDTO struct {
-	Id         string  `json:"id"`
-	SpotId     string  `json:"spot_id"`
-	TicketType string  `json:"ticket_type"`
-	Price      float64 `json:"price"`
+	Id           string  `json:"id"`
+	SpotId       string  `json:"spot_id"`
+	TicketType   string  `json:"ticket_type"`
+	Status       string  `json:"status"`
+	Price        float64 `json:"price"`
+	AttendeeName string  `json:"attendee_name,omitempty"`
+	HolderEmail  string  `json:"holder_email"`
+	SalesPhase   string  `json:"sales_phase,omitempty"`
+}
+
+type HoldDTO struct {
+	Id         string   `json:"id"`
+	EventId    string   `json:"event_id"`
+	Spots      []string `json:"spots"`
+	TicketType string   `json:"ticket_type"`
+	ExpiresAt  string   `json:"expires_at"`
+}
+
+func newEventDTO(event *domain.Event) EventDTO {
+	eventDTO := EventDTO{
+		Id:           event.Id,
+		ParentId:     event.ParentId,
+		Name:         event.Name,
+		Location:     event.Location,
+		VenueId:      event.VenueId,
+		Organization: event.Organization,
+		Rating:       string(event.Rating),
+		Date:         event.Date.UTC().Format(time.RFC3339),
+		LocalDate:    event.LocalDate().Format(time.RFC3339),
+		Timezone:     event.TimeLocation().String(),
+		ImageURL:     event.ImageURL,
+		Capacity:     event.Capacity,
+		Price:        event.Price,
+		PartnerId:    event.PartnerId,
+		SeatingMode:  string(event.SeatingMode),
+		Status:       string(event.Status),
+		SalesStartAt: formatOptionalTime(event.SalesStartAt),
+		SalesEndAt:   formatOptionalTime(event.SalesEndAt),
+		Recurrence:   newRecurrenceDTO(event.Recurrence),
+	}
+	now := time.Now()
+	eventDTO.SalesPhase = newSalesPhaseDTO(event.CurrentSalesPhase(now))
+	eventDTO.NextSalesPhase = newSalesPhaseDTO(event.NextSalesPhase(now))
+	for _, session := range event.Sessions {
+		eventDTO.Sessions = append(eventDTO.Sessions, newEventDTO(session))
+	}
+	return eventDTO
+}
+
+// formatOptionalTime leaves zero times out of the DTOs.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func newSpotDTO(spot *domain.Spot) SpotDTO {
+	attributes := make([]string, len(spot.Attributes))
+	for i, attribute := range spot.Attributes {
+		attributes[i] = string(attribute)
+	}
+
+	return SpotDTO{
+		Id:         spot.Id,
+		EventId:    spot.EventId,
+		Name:       spot.Name,
+		Zone:       spot.Zone,
+		Attributes: attributes,
+		Status:     string(spot.Status),
+		TicketId:   spot.TicketId,
+	}
+}
+
+func newTicketDTO(ticket *domain.Ticket) TicketDTO {
+	ticketDTO := TicketDTO{
+		Id:           ticket.Id,
+		TicketType:   string(ticket.TicketType),
+		Status:       string(ticket.Status),
+		Price:        ticket.Price,
+		AttendeeName: ticket.Attendee.Name,
+		HolderEmail:  ticket.HolderEmail,
+		SalesPhase:   string(ticket.SalesPhase),
+	}
+	if ticket.Spot != nil {
+		ticketDTO.SpotId = ticket.Spot.Id
+	}
+	return ticketDTO
 }
@@ -0,0 +1,48 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+type GenerateSpotsInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	SpotLayoutDTO
+}
+
+type GenerateSpotsOutputDTO struct {
+	Spots []SpotDTO `json:"spots"`
+}
+
+type GenerateSpotsUseCase struct {
+	repo        domain.EventRepository
+	spotService *domain.SpotService
+}
+
+func NewGenerateSpotsUseCase(repo domain.EventRepository, spotService *domain.SpotService) *GenerateSpotsUseCase {
+	return &GenerateSpotsUseCase{repo: repo, spotService: spotService}
+}
+
+func (uc *GenerateSpotsUseCase) Execute(input GenerateSpotsInputDTO) (*GenerateSpotsOutputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	spots, err := uc.spotService.GenerateSpots(event, input.SpotLayoutDTO.toDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateSpots(spots); err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	spotsDTO := make([]SpotDTO, len(spots))
+	for i := range spots {
+		spotsDTO[i] = newSpotDTO(&spots[i])
+	}
+
+	return &GenerateSpotsOutputDTO{Spots: spotsDTO}, nil
+}
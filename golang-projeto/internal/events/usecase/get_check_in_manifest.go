@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type GetCheckInManifestInputDTO struct {
+	OrganizationId string
+	EventId        string
+}
+
+// CheckInManifestDTO repeats the signed payload in plain fields for
+// convenience; gate devices must trust only Manifest, after verifying it
+// with the key KeyId.
+type CheckInManifestDTO struct {
+	EventId    string                     `json:"event_id"`
+	KeyId      string                     `json:"key_id"`
+	Tickets    []CheckInManifestTicketDTO `json:"tickets"`
+	IssuedAt   string                     `json:"issued_at"`
+	ValidUntil string                     `json:"valid_until"`
+	Manifest   string                     `json:"manifest"`
+}
+
+// CheckInManifestTicketDTO is a ticket allowed in; credentials with a
+// lower version were issued before a transfer and must be refused.
+type CheckInManifestTicketDTO struct {
+	TicketId          string `json:"ticket_id"`
+	CredentialVersion int    `json:"credential_version"`
+}
+
+type GetCheckInManifestUseCase struct {
+	repo   domain.EventRepository
+	signer domain.CredentialSigner
+	grace  time.Duration
+}
+
+// NewGetCheckInManifestUseCase issues manifests that stay valid for grace
+// after the event starts, like ticket credentials.
+func NewGetCheckInManifestUseCase(repo domain.EventRepository, signer domain.CredentialSigner, grace time.Duration) *GetCheckInManifestUseCase {
+	return &GetCheckInManifestUseCase{repo: repo, signer: signer, grace: grace}
+}
+
+func (uc *GetCheckInManifestUseCase) Execute(input GetCheckInManifestInputDTO) (*CheckInManifestDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := uc.repo.FindCheckInTickets(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.CheckInManifest{
+		EventId:    event.Id,
+		Tickets:    tickets,
+		IssuedAt:   time.Now().Truncate(time.Second),
+		ValidUntil: event.Date.Add(uc.grace).Truncate(time.Second),
+	}
+	signed, err := uc.signer.SignManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	ticketsDTO := make([]CheckInManifestTicketDTO, len(manifest.Tickets))
+	for i, ticket := range manifest.Tickets {
+		ticketsDTO[i] = CheckInManifestTicketDTO{TicketId: ticket.TicketId, CredentialVersion: ticket.CredentialVersion}
+	}
+	return &CheckInManifestDTO{
+		EventId:    manifest.EventId,
+		KeyId:      manifest.KeyId,
+		Tickets:    ticketsDTO,
+		IssuedAt:   manifest.IssuedAt.UTC().Format(time.RFC3339),
+		ValidUntil: manifest.ValidUntil.UTC().Format(time.RFC3339),
+		Manifest:   signed,
+	}, nil
+}
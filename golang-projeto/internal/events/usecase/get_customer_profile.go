@@ -0,0 +1,26 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+type GetCustomerProfileInputDTO struct {
+	Principal *domain.Principal
+}
+
+type GetCustomerProfileUseCase struct {
+	accounts customerAccounts
+}
+
+func NewGetCustomerProfileUseCase(customerRepo domain.CustomerRepository) *GetCustomerProfileUseCase {
+	return &GetCustomerProfileUseCase{accounts: customerAccounts{repo: customerRepo}}
+}
+
+func (uc *GetCustomerProfileUseCase) Execute(input GetCustomerProfileInputDTO) (*CustomerDTO, error) {
+
+	// Buscando (ou abrindo) a conta do usuário autenticado.
+	customer, err := uc.accounts.resolve(input.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCustomerDTO(customer), nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type GetOrderTicketsDocumentInputDTO struct {
+	OrganizationId string
+	OrderId        string
+	Principal      *domain.Principal
+}
+
+type DocumentDTO struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type GetOrderTicketsDocumentUseCase struct {
+	repo      domain.EventRepository
+	orderRepo domain.OrderRepository
+	signer    domain.CredentialSigner
+	renderer  domain.OrderDocumentRenderer
+	grace     time.Duration
+}
+
+// NewGetOrderTicketsDocumentUseCase prints ticket credentials that stay
+// valid for grace after the event starts.
+func NewGetOrderTicketsDocumentUseCase(
+	repo domain.EventRepository,
+	orderRepo domain.OrderRepository,
+	signer domain.CredentialSigner,
+	renderer domain.OrderDocumentRenderer,
+	grace time.Duration,
+) *GetOrderTicketsDocumentUseCase {
+	return &GetOrderTicketsDocumentUseCase{repo: repo, orderRepo: orderRepo, signer: signer, renderer: renderer, grace: grace}
+}
+
+func (uc *GetOrderTicketsDocumentUseCase) Execute(input GetOrderTicketsDocumentInputDTO) (*DocumentDTO, error) {
+
+	// Buscando dados em db.
+	order, err := uc.orderRepo.FindOrderById(input.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	// Pedidos de eventos de outra organização não são encontrados.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, order.EventId)
+	if errors.Is(err, domain.ErrEventNotFound) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !input.Principal.CanAccessOrder(order) {
+		return nil, domain.ErrForbidden
+	}
+
+	// Apenas ingressos ativos recebem QR code.
+	document := &domain.OrderDocument{Order: order, Event: event, Credentials: make(map[string]string)}
+	now := time.Now()
+	for i := range order.Tickets {
+		ticket := &order.Tickets[i]
+		if ticket.Status != domain.TicketStatusActive {
+			continue
+		}
+		credential, err := domain.NewTicketCredential(ticket, event, now, uc.grace)
+		if err != nil {
+			return nil, err
+		}
+		if document.Credentials[ticket.Id], err = uc.signer.Sign(credential); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := uc.renderer.RenderOrder(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocumentDTO{
+		FileName:    "tickets-" + order.Id + ".pdf",
+		ContentType: uc.renderer.ContentType(),
+		Content:     content,
+	}, nil
+}
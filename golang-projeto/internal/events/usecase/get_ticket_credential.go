@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type GetTicketCredentialInputDTO struct {
+	OrganizationId string
+	TicketId       string
+	Principal      *domain.Principal
+}
+
+type TicketCredentialDTO struct {
+	TicketId   string `json:"ticket_id"`
+	EventId    string `json:"event_id"`
+	Spot       string `json:"spot"`
+	KeyId      string `json:"key_id"`
+	ValidUntil string `json:"valid_until"`
+	Credential string `json:"credential"`
+}
+
+type GetTicketCredentialUseCase struct {
+	repo   domain.EventRepository
+	signer domain.CredentialSigner
+	grace  time.Duration
+}
+
+// NewGetTicketCredentialUseCase issues credentials that stay valid for grace
+// after the event starts.
+func NewGetTicketCredentialUseCase(repo domain.EventRepository, signer domain.CredentialSigner, grace time.Duration) *GetTicketCredentialUseCase {
+	return &GetTicketCredentialUseCase{repo: repo, signer: signer, grace: grace}
+}
+
+func (uc *GetTicketCredentialUseCase) Execute(input GetTicketCredentialInputDTO) (*TicketCredentialDTO, error) {
+
+	// Buscando dados em db.
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, input.TicketId)
+	if err != nil {
+		return nil, err
+	}
+	if !input.Principal.CanAccessTicket(ticket) {
+		return nil, domain.ErrForbidden
+	}
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, ticket.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := domain.NewTicketCredential(ticket, event, time.Now(), uc.grace)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := uc.signer.Sign(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TicketCredentialDTO{
+		TicketId:   credential.TicketId,
+		EventId:    credential.EventId,
+		Spot:       credential.Spot,
+		KeyId:      credential.KeyId,
+		ValidUntil: credential.ValidUntil.UTC().Format(time.RFC3339),
+		Credential: signed,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type GetWaitingRoomInputDTO struct {
+	OrganizationId string
+	EventId        string
+}
+
+type GetWaitingRoomUseCase struct {
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewGetWaitingRoomUseCase(roomRepo domain.WaitingRoomRepository) *GetWaitingRoomUseCase {
+	return &GetWaitingRoomUseCase{roomRepo: roomRepo}
+}
+
+func (uc *GetWaitingRoomUseCase) Execute(input GetWaitingRoomInputDTO) (*WaitingRoomDTO, error) {
+
+	// Buscando dados em db.
+	room, err := uc.roomRepo.FindOrganizationWaitingRoom(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+	waiting, err := uc.roomRepo.CountWaiting(room.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWaitingRoomDTO(room, waiting), nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type GetWaitingRoomEntryInputDTO struct {
+	OrganizationId string
+	EventId        string
+	Token          string
+}
+
+// GetWaitingRoomEntryUseCase is polled by queued customers for their
+// position and estimated wait, until they are admitted.
+type GetWaitingRoomEntryUseCase struct {
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewGetWaitingRoomEntryUseCase(roomRepo domain.WaitingRoomRepository) *GetWaitingRoomEntryUseCase {
+	return &GetWaitingRoomEntryUseCase{roomRepo: roomRepo}
+}
+
+func (uc *GetWaitingRoomEntryUseCase) Execute(input GetWaitingRoomEntryInputDTO) (*WaitingRoomEntryDTO, error) {
+
+	// Buscando dados em db.
+	entry, err := uc.roomRepo.FindWaitingRoomEntry(input.Token)
+	if err != nil {
+		return nil, err
+	}
+	if entry.EventId != input.EventId {
+		return nil, domain.ErrWaitingRoomEntryNotFound
+	}
+	room, err := uc.roomRepo.FindOrganizationWaitingRoom(input.OrganizationId, entry.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return waitingRoomEntryDTO(uc.roomRepo, room, entry)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type HoldSpotsInputDTO struct {
+	OrganizationId string   `json:"-"`
+	EventId        string   `json:"event_id"`
+	Spots          []string `json:"spots"`
+	TicketType     string   `json:"ticket_type"`
+}
+
+// HoldSpotsUseCase holds the spots picked by a customer, as opposed to
+// BestAvailableUseCase, which picks them.
+type HoldSpotsUseCase struct {
+	repo         domain.EventRepository
+	spotHub      domain.SpotAvailabilityHub
+	holdDuration time.Duration
+}
+
+func NewHoldSpotsUseCase(repo domain.EventRepository, spotHub domain.SpotAvailabilityHub, holdDuration time.Duration) *HoldSpotsUseCase {
+	return &HoldSpotsUseCase{repo: repo, spotHub: spotHub, holdDuration: holdDuration}
+}
+
+func (uc *HoldSpotsUseCase) Execute(input HoldSpotsInputDTO) (*HoldDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsCancelled() {
+		return nil, domain.ErrEventCancelled
+	}
+	if event.IsGeneralAdmission() {
+		return nil, domain.ErrEventGeneralAdmission
+	}
+
+	spots := make([]*domain.Spot, len(input.Spots))
+	for i, spotName := range input.Spots {
+		spot, err := uc.repo.FindSpotByName(event.Id, spotName)
+		if err != nil {
+			return nil, err
+		}
+		spots[i] = spot
+	}
+
+	hold, err := domain.NewHold(event, spots, domain.TicketType(input.TicketType), uc.holdDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateHold(hold); err != nil {
+		return nil, err
+	}
+	uc.spotHub.Publish(event.Id, domain.SpotChanges(hold.Spots, domain.SpotChangeHeld)...)
+
+	return newHoldDTO(hold), nil
+}
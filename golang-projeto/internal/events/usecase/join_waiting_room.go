@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type JoinWaitingRoomInputDTO struct {
+	OrganizationId string
+	EventId        string
+}
+
+// JoinWaitingRoomUseCase puts a customer at the end of the queue of an
+// event, handing them the token to poll and later check out with.
+type JoinWaitingRoomUseCase struct {
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewJoinWaitingRoomUseCase(roomRepo domain.WaitingRoomRepository) *JoinWaitingRoomUseCase {
+	return &JoinWaitingRoomUseCase{roomRepo: roomRepo}
+}
+
+func (uc *JoinWaitingRoomUseCase) Execute(input JoinWaitingRoomInputDTO) (*WaitingRoomEntryDTO, error) {
+
+	// Buscando dados em db.
+	room, err := uc.roomRepo.FindOrganizationWaitingRoom(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewWaitingRoomEntry(room)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.roomRepo.CreateWaitingRoomEntry(entry); err != nil {
+		return nil, err
+	}
+
+	return waitingRoomEntryDTO(uc.roomRepo, room, entry)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type JoinWaitlistInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	Email          string `json:"email"`
+	Quantity       int    `json:"quantity"`
+	TicketType     string `json:"ticket_type"`
+}
+
+type WaitlistEntryDTO struct {
+	Id             string `json:"id"`
+	EventId        string `json:"event_id"`
+	Email          string `json:"email"`
+	Quantity       int    `json:"quantity"`
+	TicketType     string `json:"ticket_type"`
+	Status         string `json:"status"`
+	HoldId         string `json:"hold_id,omitempty"`
+	OfferExpiresAt string `json:"offer_expires_at,omitempty"`
+}
+
+type JoinWaitlistUseCase struct {
+	repo         domain.EventRepository
+	waitlistRepo domain.WaitlistRepository
+}
+
+func NewJoinWaitlistUseCase(repo domain.EventRepository, waitlistRepo domain.WaitlistRepository) *JoinWaitlistUseCase {
+	return &JoinWaitlistUseCase{repo: repo, waitlistRepo: waitlistRepo}
+}
+
+func (uc *JoinWaitlistUseCase) Execute(input JoinWaitlistInputDTO) (*WaitlistEntryDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewWaitlistEntry(event, input.Email, input.Quantity, domain.TicketType(input.TicketType))
+	if err != nil {
+		return nil, err
+	}
+
+	// A lista de espera só aceita clientes quando não há ingressos suficientes.
+	available, err := availableTickets(uc.repo, event)
+	if err != nil {
+		return nil, err
+	}
+	if available >= entry.Quantity {
+		return nil, domain.ErrWaitlistTicketsAvailable
+	}
+
+	_, err = uc.waitlistRepo.FindWaitlistEntryByEmail(event.Id, entry.Email)
+	if err == nil {
+		return nil, domain.ErrWaitlistAlreadyRegistered
+	}
+	if !errors.Is(err, domain.ErrWaitlistEntryNotFound) {
+		return nil, err
+	}
+
+	if err := uc.waitlistRepo.CreateWaitlistEntry(entry); err != nil {
+		return nil, err
+	}
+
+	return newWaitlistEntryDTO(entry), nil
+}
+
+// availableTickets returns how many tickets of the event can be bought right
+// now: its remaining capacity, further limited to the available spots for
+// seated events.
+func availableTickets(repo domain.EventRepository, event *domain.Event) (int, error) {
+	available := event.RemainingCapacity()
+	if event.IsGeneralAdmission() {
+		return available, nil
+	}
+
+	spots, err := repo.FindSpotsByEventId(event.Id)
+	if err != nil {
+		return 0, err
+	}
+	availableSpots := 0
+	now := time.Now()
+	for _, spot := range spots {
+		if spot.IsAvailable(now) {
+			availableSpots++
+		}
+	}
+	return min(available, availableSpots), nil
+}
+
+func newWaitlistEntryDTO(entry *domain.WaitlistEntry) *WaitlistEntryDTO {
+	entryDTO := &WaitlistEntryDTO{
+		Id:         entry.Id,
+		EventId:    entry.EventId,
+		Email:      entry.Email,
+		Quantity:   entry.Quantity,
+		TicketType: string(entry.TicketType),
+		Status:     string(entry.Status),
+		HoldId:     entry.HoldId,
+	}
+	if !entry.OfferExpiresAt.IsZero() {
+		entryDTO.OfferExpiresAt = entry.OfferExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return entryDTO
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"slices"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type CredentialKeyDTO struct {
+	KeyId     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+type ListCredentialKeysOutputDTO struct {
+	Keys []CredentialKeyDTO `json:"keys"`
+}
+
+// ListCredentialKeysUseCase publishes the public keys gate devices need to
+// verify credentials and manifests offline.
+type ListCredentialKeysUseCase struct {
+	signer domain.CredentialSigner
+}
+
+func NewListCredentialKeysUseCase(signer domain.CredentialSigner) *ListCredentialKeysUseCase {
+	return &ListCredentialKeysUseCase{signer: signer}
+}
+
+func (uc *ListCredentialKeysUseCase) Execute() (*ListCredentialKeysOutputDTO, error) {
+	keys := uc.signer.PublicKeys()
+
+	keyIds := make([]string, 0, len(keys))
+	for keyId := range keys {
+		keyIds = append(keyIds, keyId)
+	}
+	slices.Sort(keyIds)
+
+	output := &ListCredentialKeysOutputDTO{Keys: make([]CredentialKeyDTO, len(keyIds))}
+	for i, keyId := range keyIds {
+		output.Keys[i] = CredentialKeyDTO{
+			KeyId:     keyId,
+			Algorithm: "Ed25519",
+			PublicKey: base64.StdEncoding.EncodeToString(keys[keyId]),
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ListCustomerOrdersInputDTO struct {
+	Principal *domain.Principal
+}
+
+type CustomerOrderDTO struct {
+	Id            string           `json:"id"`
+	Email         string           `json:"email"`
+	Total         float64          `json:"total"`
+	PaymentStatus string           `json:"payment_status"`
+	CreatedAt     string           `json:"created_at"`
+	Event         CustomerEventDTO `json:"event"`
+	Tickets       []TicketDTO      `json:"tickets"`
+}
+
+type ListCustomerOrdersOutputDTO struct {
+	Orders []CustomerOrderDTO `json:"orders"`
+}
+
+type ListCustomerOrdersUseCase struct {
+	repo     domain.CustomerRepository
+	accounts customerAccounts
+}
+
+func NewListCustomerOrdersUseCase(customerRepo domain.CustomerRepository) *ListCustomerOrdersUseCase {
+	return &ListCustomerOrdersUseCase{repo: customerRepo, accounts: customerAccounts{repo: customerRepo}}
+}
+
+func (uc *ListCustomerOrdersUseCase) Execute(input ListCustomerOrdersInputDTO) (*ListCustomerOrdersOutputDTO, error) {
+
+	customer, err := uc.accounts.resolve(input.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscando dados em db.
+	orders, err := uc.repo.FindCustomerOrders(customer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	output := ListCustomerOrdersOutputDTO{Orders: make([]CustomerOrderDTO, len(orders))}
+	for i, order := range orders {
+		tickets := make([]TicketDTO, len(order.Order.Tickets))
+		for j, ticket := range order.Order.Tickets {
+			tickets[j] = newTicketDTO(&ticket)
+		}
+		output.Orders[i] = CustomerOrderDTO{
+			Id:            order.Order.Id,
+			Email:         order.Order.Email,
+			Total:         order.Order.Total,
+			PaymentStatus: string(order.Order.PaymentStatus),
+			CreatedAt:     order.Order.CreatedAt.UTC().Format(time.RFC3339),
+			Event:         newCustomerEventDTO(&order.Event),
+			Tickets:       tickets,
+		}
+	}
+
+	return &output, nil
+}
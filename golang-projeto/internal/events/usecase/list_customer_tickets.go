@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"slices"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ListCustomerTicketsInputDTO struct {
+	Principal *domain.Principal
+}
+
+type CustomerTicketDTO struct {
+	TicketDTO
+	Spot    string           `json:"spot,omitempty"`
+	OrderId string           `json:"order_id"`
+	Event   CustomerEventDTO `json:"event"`
+}
+
+// ListCustomerTicketsOutputDTO splits the tickets by event date: upcoming
+// ones soonest first, past ones most recent first.
+type ListCustomerTicketsOutputDTO struct {
+	Upcoming []CustomerTicketDTO `json:"upcoming"`
+	Past     []CustomerTicketDTO `json:"past"`
+}
+
+type ListCustomerTicketsUseCase struct {
+	repo     domain.CustomerRepository
+	accounts customerAccounts
+}
+
+func NewListCustomerTicketsUseCase(customerRepo domain.CustomerRepository) *ListCustomerTicketsUseCase {
+	return &ListCustomerTicketsUseCase{repo: customerRepo, accounts: customerAccounts{repo: customerRepo}}
+}
+
+func (uc *ListCustomerTicketsUseCase) Execute(input ListCustomerTicketsInputDTO) (*ListCustomerTicketsOutputDTO, error) {
+
+	customer, err := uc.accounts.resolve(input.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscando dados em db.
+	tickets, err := uc.repo.FindCustomerTickets(customer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	now := time.Now()
+	output := ListCustomerTicketsOutputDTO{Upcoming: []CustomerTicketDTO{}, Past: []CustomerTicketDTO{}}
+	for _, ticket := range tickets {
+		ticketDTO := CustomerTicketDTO{
+			TicketDTO: newTicketDTO(&ticket.Ticket),
+			OrderId:   ticket.Ticket.OrderId,
+			Event:     newCustomerEventDTO(&ticket.Event),
+		}
+		if ticket.Ticket.Spot != nil {
+			ticketDTO.Spot = ticket.Ticket.Spot.Name
+		}
+
+		if ticket.Event.Date.Before(now) {
+			output.Past = append(output.Past, ticketDTO)
+		} else {
+			output.Upcoming = append(output.Upcoming, ticketDTO)
+		}
+	}
+	slices.Reverse(output.Past)
+
+	return &output, nil
+}
@@ -1,6 +1,14 @@
 package usecase
 
-import "github.com/daffc/imersao18/golang/internal/events/domain"
+import (
+	"slices"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ListEventsInputDTO struct {
+	OrganizationId string
+}
 
 type ListEventsOutputDTO struct {
 	Events []EventDTO
@@ -14,30 +22,32 @@ func NewListEvenetsUseCase(repo domain.EventRepository) *ListEventsUseCase {
 	return &ListEventsUseCase{repo: repo}
 }
 
-func (uc *ListEventsUseCase) Execute() (*ListEventsOutputDTO, error) {
+func (uc *ListEventsUseCase) Execute(input ListEventsInputDTO) (*ListEventsOutputDTO, error) {
 
 	// Buscando dados em db.
-	events, err := uc.repo.ListEvents()
+	events, err := uc.repo.ListEvents(input.OrganizationId)
 	if err != nil {
 		return nil, err
 	}
 
-	eventsDTO := make([]EventDTO, len(events))
-
-	// Ajustando dados a DTO para serem entregues a cliente.
-	for i, event := range events {
-		eventsDTO[i] = EventDTO{
-			Id:           event.Id,
-			Name:         event.Name,
-			Location:     event.Location,
-			Organization: event.Organization,
-			Rating:       string(event.Rating),
-			Date:         event.Date.Format("0000-00-00 00:00:00"),
-			ImageURL:     event.ImageURL,
-			Capacity:     event.Capacity,
-			Price:        event.Price,
-			PartnerId:    event.PartnerId,
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	// Ajustando dados a DTO para serem entregues a cliente; as sessões de
+	// eventos recorrentes são listadas dentro do evento pai.
+	eventsDTO := make([]EventDTO, 0, len(events))
+	sessionsDTO := make(map[string][]EventDTO)
+	for _, event := range events {
+		eventDTO := newEventDTO(&event)
+		if event.IsSession() {
+			sessionsDTO[event.ParentId] = append(sessionsDTO[event.ParentId], eventDTO)
+			continue
 		}
+		eventsDTO = append(eventsDTO, eventDTO)
+	}
+	for i := range eventsDTO {
+		eventsDTO[i].Sessions = sessionsDTO[eventsDTO[i].Id]
 	}
 
 	return &ListEventsOutputDTO{Events: eventsDTO}, nil
@@ -2,8 +2,13 @@ package usecase
 
 import "github.com/daffc/imersao18/golang/internal/events/domain"
 
+// ListSpotsInputDTO optionally filters spots by status and by attributes;
+// a spot must have every attribute listed to be returned.
 type ListSpotsInputDTO struct {
-	EventId string
+	OrganizationId string
+	EventId        string
+	Status         string
+	Attributes     []string
 }
 
 type ListSpotsOutputDTO struct {
@@ -22,7 +27,7 @@ func NewListSpotsUseCase(repo domain.EventRepository) *ListSpotsUseCase {
 func (uc *ListSpotsUseCase) Execute(input ListSpotsInputDTO) (*ListSpotsOutputDTO, error) {
 
 	// Buscando dados em db.
-	event, err := uc.repo.FindEventById(input.EventId)
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
 	if err != nil {
 		return nil, err
 	}
@@ -33,29 +38,26 @@ func (uc *ListSpotsUseCase) Execute(input ListSpotsInputDTO) (*ListSpotsOutputDT
 	}
 
 	// Ajustando dados a DTO para serem entregues a cliente.
-	eventDTO := EventDTO{
-		Id:           event.Id,
-		Name:         event.Name,
-		Location:     event.Location,
-		Organization: event.Organization,
-		Rating:       string(event.Rating),
-		Date:         event.Date.Format("0000-00-00 00:00:00"),
-		ImageURL:     event.ImageURL,
-		Capacity:     event.Capacity,
-		Price:        event.Price,
-		PartnerId:    event.PartnerId,
-	}
+	eventDTO := newEventDTO(event)
 
-	spotsDTO := make([]SpotDTO, len(spots))
-	for i, spot := range spots {
-		spotsDTO[i] = SpotDTO{
-			Id:       spot.Id,
-			EventId:  spot.EventId,
-			Name:     spot.Name,
-			Status:   string(spot.Status),
-			TicketId: spot.TicketId,
+	spotsDTO := make([]SpotDTO, 0, len(spots))
+	for _, spot := range spots {
+		if input.matches(spot) {
+			spotsDTO = append(spotsDTO, newSpotDTO(spot))
 		}
 	}
 
 	return &ListSpotsOutputDTO{Event: eventDTO, Spots: spotsDTO}, nil
 }
+
+func (input ListSpotsInputDTO) matches(spot *domain.Spot) bool {
+	if input.Status != "" && string(spot.Status) != input.Status {
+		return false
+	}
+	for _, attribute := range input.Attributes {
+		if !spot.HasAttribute(domain.SpotAttribute(attribute)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ListTicketHoldersInputDTO struct {
+	OrganizationId string
+	TicketId       string
+}
+
+type TicketHolderDTO struct {
+	Email      string `json:"email"`
+	TransferId string `json:"transfer_id,omitempty"`
+	Since      string `json:"since"`
+}
+
+type ListTicketHoldersOutputDTO struct {
+	Holders []TicketHolderDTO `json:"holders"`
+}
+
+type ListTicketHoldersUseCase struct {
+	repo         domain.EventRepository
+	transferRepo domain.TicketTransferRepository
+}
+
+func NewListTicketHoldersUseCase(repo domain.EventRepository, transferRepo domain.TicketTransferRepository) *ListTicketHoldersUseCase {
+	return &ListTicketHoldersUseCase{repo: repo, transferRepo: transferRepo}
+}
+
+func (uc *ListTicketHoldersUseCase) Execute(input ListTicketHoldersInputDTO) (*ListTicketHoldersOutputDTO, error) {
+
+	// Buscando dados em db.
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, input.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	holders, err := uc.transferRepo.FindTicketHolders(ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	holdersDTO := make([]TicketHolderDTO, len(holders))
+	for i, holder := range holders {
+		holdersDTO[i] = TicketHolderDTO{
+			Email:      holder.Email,
+			TransferId: holder.TransferId,
+			Since:      holder.Since.UTC().Format(time.RFC3339),
+		}
+	}
+
+	return &ListTicketHoldersOutputDTO{Holders: holdersDTO}, nil
+}
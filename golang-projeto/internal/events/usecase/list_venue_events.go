@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ListVenueEventsInputDTO struct {
+	OrganizationId string
+	VenueId        string
+}
+
+type ListVenueEventsOutputDTO struct {
+	Venue  VenueDTO   `json:"venue"`
+	Events []EventDTO `json:"events"`
+}
+
+// ListVenueEventsUseCase lists the events still to happen at a venue,
+// cancelled ones included so that customers can find out about them.
+type ListVenueEventsUseCase struct {
+	venueRepo domain.VenueRepository
+}
+
+func NewListVenueEventsUseCase(venueRepo domain.VenueRepository) *ListVenueEventsUseCase {
+	return &ListVenueEventsUseCase{venueRepo: venueRepo}
+}
+
+func (uc *ListVenueEventsUseCase) Execute(input ListVenueEventsInputDTO) (*ListVenueEventsOutputDTO, error) {
+
+	// Buscando dados em db.
+	venue, err := uc.venueRepo.FindOrganizationVenue(input.OrganizationId, input.VenueId)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.venueRepo.FindUpcomingVenueEvents(venue.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	eventsDTO := make([]EventDTO, len(events))
+	for i, event := range events {
+		eventsDTO[i] = newEventDTO(event)
+	}
+
+	return &ListVenueEventsOutputDTO{Venue: newVenueDTO(venue), Events: eventsDTO}, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+type ListVenuesInputDTO struct {
+	OrganizationId string
+}
+
+type ListVenuesOutputDTO struct {
+	Venues []VenueDTO `json:"venues"`
+}
+
+type ListVenuesUseCase struct {
+	venueRepo domain.VenueRepository
+}
+
+func NewListVenuesUseCase(venueRepo domain.VenueRepository) *ListVenuesUseCase {
+	return &ListVenuesUseCase{venueRepo: venueRepo}
+}
+
+func (uc *ListVenuesUseCase) Execute(input ListVenuesInputDTO) (*ListVenuesOutputDTO, error) {
+
+	// Buscando dados em db.
+	venues, err := uc.venueRepo.ListVenues(input.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	venuesDTO := make([]VenueDTO, len(venues))
+	for i, venue := range venues {
+		venuesDTO[i] = newVenueDTO(venue)
+	}
+
+	return &ListVenuesOutputDTO{Venues: venuesDTO}, nil
+}
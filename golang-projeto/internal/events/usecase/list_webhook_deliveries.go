@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 500
+)
+
+type ListWebhookDeliveriesInputDTO struct {
+	OrganizationId string
+	WebhookId      string
+	Status         string
+	Limit          int
+}
+
+type ListWebhookDeliveriesOutputDTO struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+}
+
+// ListWebhookDeliveriesUseCase is the delivery log of a webhook, newest
+// first. Filtering by the dead status gives its dead-letter list.
+type ListWebhookDeliveriesUseCase struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewListWebhookDeliveriesUseCase(webhookRepo domain.WebhookRepository) *ListWebhookDeliveriesUseCase {
+	return &ListWebhookDeliveriesUseCase{webhookRepo: webhookRepo}
+}
+
+func (uc *ListWebhookDeliveriesUseCase) Execute(input ListWebhookDeliveriesInputDTO) (*ListWebhookDeliveriesOutputDTO, error) {
+	status := domain.WebhookDeliveryStatus(input.Status)
+	if status != "" && !domain.IsValidWebhookDeliveryStatus(status) {
+		return nil, domain.ErrWebhookInvalidDeliveryStatus
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+	limit = min(limit, maxWebhookDeliveriesLimit)
+
+	// Buscando dados em db.
+	subscription, err := uc.webhookRepo.FindOrganizationSubscription(input.OrganizationId, input.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := uc.webhookRepo.FindDeliveries(subscription.Id, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	deliveriesDTO := make([]WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveriesDTO[i] = newWebhookDeliveryDTO(delivery)
+	}
+	return &ListWebhookDeliveriesOutputDTO{Deliveries: deliveriesDTO}, nil
+}
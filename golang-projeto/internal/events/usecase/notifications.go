@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// notificationOutbox composes notifications and stores them in the outbox,
+// from where DispatchNotificationsUseCase sends them.
+type notificationOutbox struct {
+	repo    domain.NotificationRepository
+	service *domain.NotificationService
+}
+
+func (o notificationOutbox) enqueue(kind domain.NotificationKind, dedupKey string, data *domain.NotificationData) error {
+	notification, err := o.service.Compose(kind, dedupKey, data)
+	if err != nil {
+		return err
+	}
+	return o.repo.CreateNotification(notification)
+}
+
+// enqueueForHolders sends one notification per ticket holder, listing only
+// the tickets they hold. dedupKey is suffixed with the holder email.
+func (o notificationOutbox) enqueueForHolders(kind domain.NotificationKind, dedupKey string, event *domain.Event, tickets []domain.Ticket, reason string) error {
+	var holders []string
+	byHolder := make(map[string][]domain.Ticket)
+	for _, ticket := range tickets {
+		if ticket.HolderEmail == "" {
+			continue
+		}
+		if _, ok := byHolder[ticket.HolderEmail]; !ok {
+			holders = append(holders, ticket.HolderEmail)
+		}
+		byHolder[ticket.HolderEmail] = append(byHolder[ticket.HolderEmail], ticket)
+	}
+
+	for _, holder := range holders {
+		data := &domain.NotificationData{Recipient: holder, Event: event, Tickets: byHolder[holder], Reason: reason}
+		if err := o.enqueue(kind, dedupKey+":"+holder, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type OfferWaitlistSpotsInputDTO struct {
+	EventId string
+}
+
+type OfferWaitlistSpotsOutputDTO struct {
+	Offers []WaitlistEntryDTO `json:"offers"`
+}
+
+// OfferWaitlistSpotsUseCase hands released tickets of an event to the
+// customers on its waitlist, in order, as time-limited holds. It stops at
+// the first entry that cannot be served so nobody is skipped.
+type OfferWaitlistSpotsUseCase struct {
+	repo                 domain.EventRepository
+	waitlistRepo         domain.WaitlistRepository
+	seatSelectionService *domain.SeatSelectionService
+	notifier             domain.WaitlistNotifier
+	spotHub              domain.SpotAvailabilityHub
+	offerDuration        time.Duration
+}
+
+func NewOfferWaitlistSpotsUseCase(
+	repo domain.EventRepository,
+	waitlistRepo domain.WaitlistRepository,
+	seatSelectionService *domain.SeatSelectionService,
+	notifier domain.WaitlistNotifier,
+	spotHub domain.SpotAvailabilityHub,
+	offerDuration time.Duration,
+) *OfferWaitlistSpotsUseCase {
+	return &OfferWaitlistSpotsUseCase{
+		repo:                 repo,
+		waitlistRepo:         waitlistRepo,
+		seatSelectionService: seatSelectionService,
+		notifier:             notifier,
+		spotHub:              spotHub,
+		offerDuration:        offerDuration,
+	}
+}
+
+func (uc *OfferWaitlistSpotsUseCase) Execute(input OfferWaitlistSpotsInputDTO) (*OfferWaitlistSpotsOutputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindEventById(input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := availableTickets(uc.repo, event)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &OfferWaitlistSpotsOutputDTO{Offers: []WaitlistEntryDTO{}}
+	for {
+		entry, err := uc.waitlistRepo.FindNextWaitlistEntry(event.Id)
+		if errors.Is(err, domain.ErrWaitlistEntryNotFound) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Quantity > remaining {
+			return output, nil
+		}
+
+		hold, err := uc.hold(event, entry)
+		if errors.Is(err, domain.ErrSeatSelectionNotAvailable) || errors.Is(err, domain.ErrSpotHeld) || errors.Is(err, domain.ErrEventSoldOut) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := entry.Offer(hold.Id, hold.ExpiresAt); err != nil {
+			return nil, err
+		}
+		if err := uc.waitlistRepo.UpdateWaitlistEntry(entry); err != nil {
+			return nil, err
+		}
+		if err := uc.notifier.NotifyWaitlistOffer(entry, event, hold); err != nil {
+			return nil, err
+		}
+
+		remaining -= entry.Quantity
+		output.Offers = append(output.Offers, *newWaitlistEntryDTO(entry))
+	}
+}
+
+// hold reserves the best available spots for a waitlist entry, or the
+// tickets it waits for when the event is general admission, with the
+// ticket type of the entry. Only the customer of the entry can buy the
+// hold.
+func (uc *OfferWaitlistSpotsUseCase) hold(event *domain.Event, entry *domain.WaitlistEntry) (*domain.Hold, error) {
+	if event.IsGeneralAdmission() {
+		hold, err := domain.NewGeneralAdmissionHold(event, entry.Quantity, entry.TicketType, uc.offerDuration)
+		if err != nil {
+			return nil, err
+		}
+		hold.BindTo(entry.Email)
+		if err := uc.repo.CreateHold(hold); err != nil {
+			return nil, err
+		}
+		return hold, nil
+	}
+
+	spots, err := uc.repo.FindSpotsByEventId(event.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := uc.seatSelectionService.BestAvailable(spots, entry.Quantity, "", time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := domain.NewHold(event, selected, entry.TicketType, uc.offerDuration)
+	if err != nil {
+		return nil, err
+	}
+	hold.BindTo(entry.Email)
+	if err := uc.repo.CreateHold(hold); err != nil {
+		return nil, err
+	}
+	uc.spotHub.Publish(event.Id, domain.SpotChanges(hold.Spots, domain.SpotChangeHeld)...)
+	return hold, nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"log"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// paymentRefunds gives customers their money back for cancelled tickets.
+// Tickets are refunded per order, for the price each one was sold at, and
+// only when the order was charged.
+type paymentRefunds struct {
+	orderRepo domain.OrderRepository
+	gateway   domain.PaymentGateway
+}
+
+// refund is called once the tickets are cancelled, so failures are only
+// logged: the cancellation stands and the refund can be retried on the
+// gateway.
+func (p paymentRefunds) refund(tickets []domain.Ticket) {
+	amounts := make(map[string]float64)
+	var orderIds []string
+	for _, ticket := range tickets {
+		if _, ok := amounts[ticket.OrderId]; !ok {
+			orderIds = append(orderIds, ticket.OrderId)
+		}
+		amounts[ticket.OrderId] = domain.RoundAmount(amounts[ticket.OrderId] + ticket.Price)
+	}
+
+	for _, orderId := range orderIds {
+		if err := p.refundOrder(orderId, amounts[orderId]); err != nil {
+			log.Printf("refund order %s: %v", orderId, err)
+		}
+	}
+}
+
+func (p paymentRefunds) refundOrder(orderId string, amount float64) error {
+	order, err := p.orderRepo.FindOrderById(orderId)
+	if err != nil {
+		return err
+	}
+	// Pedidos não cobrados (ou gratuitos) não têm o que devolver.
+	if order.Refund() != nil || amount <= 0 {
+		return nil
+	}
+	if err := p.gateway.Refund(order.PaymentId, amount); err != nil {
+		return err
+	}
+	return p.orderRepo.UpdateOrderPayment(order)
+}
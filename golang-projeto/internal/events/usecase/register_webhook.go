@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type RegisterWebhookInputDTO struct {
+	OrganizationId string   `json:"-"`
+	URL            string   `json:"url"`
+	Secret         string   `json:"secret"`
+	EventTypes     []string `json:"event_types"`
+}
+
+// RegisterWebhookUseCase subscribes an integrator endpoint to domain
+// events. The secret is only returned here, when it was generated for the
+// integrator.
+type RegisterWebhookUseCase struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewRegisterWebhookUseCase(webhookRepo domain.WebhookRepository) *RegisterWebhookUseCase {
+	return &RegisterWebhookUseCase{webhookRepo: webhookRepo}
+}
+
+func (uc *RegisterWebhookUseCase) Execute(input RegisterWebhookInputDTO) (*WebhookSubscriptionDTO, error) {
+	subscription, err := domain.NewWebhookSubscription(input.OrganizationId, input.URL, input.Secret, input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.webhookRepo.CreateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	output := &WebhookSubscriptionDTO{
+		Id:         subscription.Id,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if input.Secret == "" {
+		output.Secret = subscription.Secret
+	}
+	return output, nil
+}
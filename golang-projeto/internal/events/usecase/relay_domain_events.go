@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type RelayDomainEventsOutputDTO struct {
+	Published int `json:"published"`
+}
+
+// RelayDomainEventsUseCase is run periodically to publish the events of
+// the outbox in the order they were stored. It stops at the first failure,
+// so a later event is never published before an earlier one.
+type RelayDomainEventsUseCase struct {
+	eventRepo domain.DomainEventRepository
+	publisher domain.DomainEventPublisher
+	batchSize int
+}
+
+func NewRelayDomainEventsUseCase(eventRepo domain.DomainEventRepository, publisher domain.DomainEventPublisher, batchSize int) *RelayDomainEventsUseCase {
+	return &RelayDomainEventsUseCase{eventRepo: eventRepo, publisher: publisher, batchSize: batchSize}
+}
+
+func (uc *RelayDomainEventsUseCase) Execute() (*RelayDomainEventsOutputDTO, error) {
+	events, err := uc.eventRepo.FindUnpublishedEvents(uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RelayDomainEventsOutputDTO{}
+	for _, event := range events {
+		if err := uc.publisher.Publish(event); err != nil {
+			return output, err
+		}
+		if err := uc.eventRepo.MarkEventPublished(event.Id, time.Now()); err != nil {
+			return output, err
+		}
+		output.Published++
+	}
+	return output, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ReleaseHoldInputDTO struct {
+	EventId string `json:"event_id"`
+	HoldId  string `json:"hold_id"`
+}
+
+// ReleaseHoldUseCase gives up a hold before it expires, making its spots
+// available again.
+type ReleaseHoldUseCase struct {
+	repo    domain.EventRepository
+	spotHub domain.SpotAvailabilityHub
+}
+
+func NewReleaseHoldUseCase(repo domain.EventRepository, spotHub domain.SpotAvailabilityHub) *ReleaseHoldUseCase {
+	return &ReleaseHoldUseCase{repo: repo, spotHub: spotHub}
+}
+
+func (uc *ReleaseHoldUseCase) Execute(input ReleaseHoldInputDTO) (*HoldDTO, error) {
+
+	// Buscando dados em db.
+	hold, err := uc.repo.FindHoldById(input.HoldId)
+	if err != nil {
+		return nil, err
+	}
+	if hold.EventId != input.EventId {
+		return nil, domain.ErrHoldNotFound
+	}
+
+	if err := uc.repo.ReleaseHold(hold.Id); err != nil {
+		return nil, err
+	}
+	for _, spot := range hold.Spots {
+		spot.Release()
+	}
+	uc.spotHub.Publish(hold.EventId, domain.SpotChanges(hold.Spots, domain.SpotChangeReleased)...)
+
+	return newHoldDTO(hold), nil
+}
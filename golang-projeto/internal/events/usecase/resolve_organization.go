@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// ResolveOrganizationInputDTO identifies the tenant of a request by Slug,
+// when given, or else by Host.
+type ResolveOrganizationInputDTO struct {
+	Slug      string
+	Host      string
+	Principal *domain.Principal
+}
+
+type BrandingDTO struct {
+	LogoURL      string `json:"logo_url"`
+	PrimaryColor string `json:"primary_color"`
+	SupportEmail string `json:"support_email"`
+}
+
+type OrganizationDTO struct {
+	Id       string      `json:"id"`
+	Slug     string      `json:"slug"`
+	Name     string      `json:"name"`
+	Branding BrandingDTO `json:"branding"`
+}
+
+// ResolveOrganizationOutputDTO has a nil Organization when the request is
+// not bound to any tenant. Principal is the authenticated user with the
+// roles they have inside the organization.
+type ResolveOrganizationOutputDTO struct {
+	Organization *OrganizationDTO
+	Principal    *domain.Principal
+}
+
+type ResolveOrganizationUseCase struct {
+	repo domain.OrganizationRepository
+}
+
+func NewResolveOrganizationUseCase(repo domain.OrganizationRepository) *ResolveOrganizationUseCase {
+	return &ResolveOrganizationUseCase{repo: repo}
+}
+
+func (uc *ResolveOrganizationUseCase) Execute(input ResolveOrganizationInputDTO) (*ResolveOrganizationOutputDTO, error) {
+
+	// Um slug informado precisa existir; um host desconhecido apenas não
+	// pertence a nenhuma organização.
+	var organization *domain.Organization
+	var err error
+	if input.Slug != "" {
+		organization, err = uc.repo.FindOrganizationBySlug(input.Slug)
+	} else {
+		organization, err = uc.repo.FindOrganizationByHost(domain.NormalizeHost(input.Host))
+		if errors.Is(err, domain.ErrOrganizationNotFound) {
+			return &ResolveOrganizationOutputDTO{Principal: input.Principal}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResolveOrganizationOutputDTO{
+		Organization: &OrganizationDTO{
+			Id:   organization.Id,
+			Slug: organization.Slug,
+			Name: organization.Name,
+			Branding: BrandingDTO{
+				LogoURL:      organization.Branding.LogoURL,
+				PrimaryColor: organization.Branding.PrimaryColor,
+				SupportEmail: organization.Branding.SupportEmail,
+			},
+		},
+		Principal: organization.Authorize(input.Principal),
+	}, nil
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type RestoreAdmissionInputDTO struct {
+	Token string
+}
+
+// RestoreAdmissionUseCase gives back an admission token consumed by a
+// checkout that failed, so its customer can try again while it is valid.
+type RestoreAdmissionUseCase struct {
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewRestoreAdmissionUseCase(roomRepo domain.WaitingRoomRepository) *RestoreAdmissionUseCase {
+	return &RestoreAdmissionUseCase{roomRepo: roomRepo}
+}
+
+func (uc *RestoreAdmissionUseCase) Execute(input RestoreAdmissionInputDTO) error {
+	return uc.roomRepo.RestoreWaitingRoomEntry(input.Token)
+}
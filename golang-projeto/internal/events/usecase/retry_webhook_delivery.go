@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type RetryWebhookDeliveryInputDTO struct {
+	OrganizationId string
+	WebhookId      string
+	DeliveryId     string
+}
+
+// RetryWebhookDeliveryUseCase takes a delivery out of the dead-letter list
+// and queues it again, typically once the integrator fixed their endpoint.
+type RetryWebhookDeliveryUseCase struct {
+	webhookRepo domain.WebhookRepository
+}
+
+func NewRetryWebhookDeliveryUseCase(webhookRepo domain.WebhookRepository) *RetryWebhookDeliveryUseCase {
+	return &RetryWebhookDeliveryUseCase{webhookRepo: webhookRepo}
+}
+
+func (uc *RetryWebhookDeliveryUseCase) Execute(input RetryWebhookDeliveryInputDTO) (*WebhookDeliveryDTO, error) {
+	// Buscando dados em db.
+	subscription, err := uc.webhookRepo.FindOrganizationSubscription(input.OrganizationId, input.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := uc.webhookRepo.FindDeliveryById(input.DeliveryId)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionId != subscription.Id {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	if err := delivery.Retry(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	output := newWebhookDeliveryDTO(delivery)
+	return &output, nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// SalesPhaseDTO shows a sales phase to customers. Remaining is only set
+// for phases with a quota; the access code itself is never shown.
+type SalesPhaseDTO struct {
+	Kind               string  `json:"kind"`
+	StartsAt           string  `json:"starts_at"`
+	EndsAt             string  `json:"ends_at"`
+	Price              float64 `json:"price"`
+	Quota              int     `json:"quota,omitempty"`
+	Remaining          *int    `json:"remaining,omitempty"`
+	RequiresAccessCode bool    `json:"requires_access_code"`
+}
+
+func newSalesPhaseDTO(phase *domain.SalesPhase) *SalesPhaseDTO {
+	if phase == nil {
+		return nil
+	}
+	phaseDTO := &SalesPhaseDTO{
+		Kind:               string(phase.Kind),
+		StartsAt:           phase.StartsAt.UTC().Format(time.RFC3339),
+		EndsAt:             phase.EndsAt.UTC().Format(time.RFC3339),
+		Price:              phase.Price,
+		Quota:              phase.Quota,
+		RequiresAccessCode: phase.AccessCodeHash != "",
+	}
+	if phase.Quota > 0 {
+		remaining := max(phase.Quota-phase.SoldTickets, 0)
+		phaseDTO.Remaining = &remaining
+	}
+	return phaseDTO
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type SendEventRemindersOutputDTO struct {
+	Events int `json:"events"`
+}
+
+// SendEventRemindersUseCase is run periodically to remind ticket holders of
+// the events starting within lead.
+type SendEventRemindersUseCase struct {
+	repo   domain.EventRepository
+	outbox notificationOutbox
+	lead   time.Duration
+}
+
+func NewSendEventRemindersUseCase(repo domain.EventRepository, notificationRepo domain.NotificationRepository, notificationService *domain.NotificationService, lead time.Duration) *SendEventRemindersUseCase {
+	return &SendEventRemindersUseCase{
+		repo:   repo,
+		outbox: notificationOutbox{repo: notificationRepo, service: notificationService},
+		lead:   lead,
+	}
+}
+
+func (uc *SendEventRemindersUseCase) Execute() (*SendEventRemindersOutputDTO, error) {
+	now := time.Now()
+	events, err := uc.repo.FindEventsToRemind(now, now.Add(uc.lead))
+	if err != nil {
+		return nil, err
+	}
+
+	// O evento só é marcado após enfileirar todos os lembretes; se algo
+	// falhar no meio, a próxima execução reenfileira e as chaves de
+	// deduplicação evitam lembretes repetidos.
+	for _, event := range events {
+		tickets, err := uc.repo.FindActiveTicketsByEventId(event.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.outbox.enqueueForHolders(domain.NotificationEventReminder, "event_reminder:"+event.Id, event, tickets, ""); err != nil {
+			return nil, err
+		}
+		if err := uc.repo.MarkEventReminded(event.Id, now); err != nil {
+			return nil, err
+		}
+	}
+	return &SendEventRemindersOutputDTO{Events: len(events)}, nil
+}
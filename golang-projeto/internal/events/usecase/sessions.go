@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// RecurrenceDTO describes the sessions of a recurring event. Weekdays are
+// lowercase English names, like "thursday". Until is a date, like
+// "2025-12-31", or a date and time, both in the venue time zone.
+type RecurrenceDTO struct {
+	Frequency string   `json:"frequency"`
+	Interval  int      `json:"interval,omitempty"`
+	Weekdays  []string `json:"weekdays,omitempty"`
+	Until     string   `json:"until,omitempty"`
+	Count     int      `json:"count,omitempty"`
+}
+
+// toDomain reads the rule in the venue time zone. Interval defaults to 1.
+func (dto *RecurrenceDTO) toDomain(location *time.Location) (*domain.RecurrenceRule, error) {
+	rule := &domain.RecurrenceRule{
+		Frequency: domain.RecurrenceFrequency(dto.Frequency),
+		Interval:  dto.Interval,
+		Count:     dto.Count,
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+
+	for _, name := range dto.Weekdays {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		rule.Weekdays = append(rule.Weekdays, weekday)
+	}
+
+	if dto.Until != "" {
+		// Uma data sem horário inclui as sessões de todo aquele dia.
+		if day, err := time.ParseInLocation(time.DateOnly, dto.Until, location); err == nil {
+			rule.Until = day.AddDate(0, 0, 1).Add(-time.Second)
+		} else if rule.Until, err = parseEventDate(dto.Until, location); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(name, weekday.String()) {
+			return weekday, nil
+		}
+	}
+	return 0, domain.ErrRecurrenceInvalidWeekday
+}
+
+func newRecurrenceDTO(rule *domain.RecurrenceRule) *RecurrenceDTO {
+	if rule == nil {
+		return nil
+	}
+	recurrenceDTO := &RecurrenceDTO{
+		Frequency: string(rule.Frequency),
+		Interval:  rule.Interval,
+		Count:     rule.Count,
+	}
+	for _, weekday := range rule.Weekdays {
+		recurrenceDTO.Weekdays = append(recurrenceDTO.Weekdays, strings.ToLower(weekday.String()))
+	}
+	if !rule.Until.IsZero() {
+		recurrenceDTO.Until = rule.Until.UTC().Format(time.RFC3339)
+	}
+	return recurrenceDTO
+}
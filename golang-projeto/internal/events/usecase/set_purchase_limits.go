@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type SetPurchaseLimitsInputDTO struct {
+	OrganizationId string `json:"-"`
+	EventId        string `json:"event_id"`
+	MaxPerOrder    int    `json:"max_per_order"`
+	MaxPerEmail    int    `json:"max_per_email"`
+	MaxPerCard     int    `json:"max_per_card"`
+	MaxPerWindow   int    `json:"max_per_window"`
+	WindowSeconds  int    `json:"window_seconds"`
+}
+
+type SetPurchaseLimitsUseCase struct {
+	repo domain.EventRepository
+}
+
+func NewSetPurchaseLimitsUseCase(repo domain.EventRepository) *SetPurchaseLimitsUseCase {
+	return &SetPurchaseLimitsUseCase{repo: repo}
+}
+
+func (uc *SetPurchaseLimitsUseCase) Execute(input SetPurchaseLimitsInputDTO) (*SetPurchaseLimitsInputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := &domain.PurchaseLimits{
+		EventId:      event.Id,
+		MaxPerOrder:  input.MaxPerOrder,
+		MaxPerEmail:  input.MaxPerEmail,
+		MaxPerCard:   input.MaxPerCard,
+		MaxPerWindow: input.MaxPerWindow,
+		Window:       time.Duration(input.WindowSeconds) * time.Second,
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SavePurchaseLimits(limits); err != nil {
+		return nil, err
+	}
+
+	return &input, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// SetSalesPhasesInputDTO replaces the sales phases of an event. Dates are
+// either local to the event time zone, like "2025-12-01T10:00", or RFC 3339
+// with an offset. The presale requires AccessCode the first time; later it
+// keeps its code unless a new one is given.
+type SetSalesPhasesInputDTO struct {
+	OrganizationId string               `json:"-"`
+	EventId        string               `json:"-"`
+	Phases         []SalesPhaseInputDTO `json:"phases"`
+}
+
+type SalesPhaseInputDTO struct {
+	Kind       string  `json:"kind"`
+	StartsAt   string  `json:"starts_at"`
+	EndsAt     string  `json:"ends_at"`
+	Price      float64 `json:"price"`
+	Quota      int     `json:"quota"`
+	AccessCode string  `json:"access_code"`
+}
+
+type SetSalesPhasesOutputDTO struct {
+	EventId string          `json:"event_id"`
+	Phases  []SalesPhaseDTO `json:"phases"`
+}
+
+type SetSalesPhasesUseCase struct {
+	repo domain.EventRepository
+}
+
+func NewSetSalesPhasesUseCase(repo domain.EventRepository) *SetSalesPhasesUseCase {
+	return &SetSalesPhasesUseCase{repo: repo}
+}
+
+func (uc *SetSalesPhasesUseCase) Execute(input SetSalesPhasesInputDTO) (*SetSalesPhasesOutputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	phases := make([]domain.SalesPhase, len(input.Phases))
+	for i, phaseDTO := range input.Phases {
+		phase := domain.SalesPhase{
+			Kind:  domain.SalesPhaseKind(phaseDTO.Kind),
+			Price: phaseDTO.Price,
+			Quota: phaseDTO.Quota,
+		}
+		if phase.StartsAt, err = parseEventDate(phaseDTO.StartsAt, event.TimeLocation()); err != nil {
+			return nil, err
+		}
+		if phase.EndsAt, err = parseEventDate(phaseDTO.EndsAt, event.TimeLocation()); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(phaseDTO.AccessCode) != "" {
+			phase.AccessCodeHash = domain.HashAccessCode(phaseDTO.AccessCode)
+		}
+		phases[i] = phase
+	}
+	if err := event.SetSalesPhases(phases); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SaveSalesPhases(event); err != nil {
+		return nil, err
+	}
+
+	// Ajustando dados a DTO para serem entregues a cliente.
+	phasesDTO := make([]SalesPhaseDTO, len(event.SalesPhases))
+	for i := range event.SalesPhases {
+		phasesDTO[i] = *newSalesPhaseDTO(&event.SalesPhases[i])
+	}
+
+	return &SetSalesPhasesOutputDTO{EventId: event.Id, Phases: phasesDTO}, nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type TransferTicketInputDTO struct {
+	OrganizationId string `json:"-"`
+	TicketId       string `json:"ticket_id"`
+	FromEmail      string `json:"from_email"`
+	ToEmail        string `json:"to_email"`
+}
+
+// TicketTransferDTO carries the plain Token only when the transfer is
+// created; the holder forwards it to the recipient.
+type TicketTransferDTO struct {
+	Id        string `json:"id"`
+	TicketId  string `json:"ticket_id"`
+	FromEmail string `json:"from_email"`
+	ToEmail   string `json:"to_email"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at"`
+	Token     string `json:"token,omitempty"`
+}
+
+type TransferTicketUseCase struct {
+	repo         domain.EventRepository
+	transferRepo domain.TicketTransferRepository
+	duration     time.Duration
+}
+
+func NewTransferTicketUseCase(repo domain.EventRepository, transferRepo domain.TicketTransferRepository, duration time.Duration) *TransferTicketUseCase {
+	return &TransferTicketUseCase{repo: repo, transferRepo: transferRepo, duration: duration}
+}
+
+func (uc *TransferTicketUseCase) Execute(input TransferTicketInputDTO) (*TicketTransferDTO, error) {
+
+	// Buscando dados em db.
+	ticket, err := uc.repo.FindOrganizationTicket(input.OrganizationId, input.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apenas uma transferência pendente por ingresso; pendências vencidas são
+	// encerradas antes de iniciar uma nova.
+	pending, err := uc.transferRepo.FindPendingTransferByTicketId(ticket.Id)
+	switch {
+	case err == nil:
+		if !pending.Expire(time.Now()) {
+			return nil, domain.ErrTicketTransferPending
+		}
+		if err := uc.transferRepo.UpdateTransfer(pending); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, domain.ErrTicketTransferNotFound):
+		return nil, err
+	}
+
+	transfer, token, err := domain.NewTicketTransfer(ticket, input.FromEmail, input.ToEmail, uc.duration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.transferRepo.CreateTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	transferDTO := newTicketTransferDTO(transfer)
+	transferDTO.Token = token
+	return transferDTO, nil
+}
+
+func newTicketTransferDTO(transfer *domain.TicketTransfer) *TicketTransferDTO {
+	return &TicketTransferDTO{
+		Id:        transfer.Id,
+		TicketId:  transfer.TicketId,
+		FromEmail: transfer.FromEmail,
+		ToEmail:   transfer.ToEmail,
+		Status:    string(transfer.Status),
+		ExpiresAt: transfer.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
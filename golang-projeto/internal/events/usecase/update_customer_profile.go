@@ -0,0 +1,43 @@
+package usecase
+
+import "github.com/daffc/imersao18/golang/internal/events/domain"
+
+// UpdateCustomerProfileInputDTO replaces the profile of the authenticated
+// customer. Emails come from the access tokens and cannot be edited.
+type UpdateCustomerProfileInputDTO struct {
+	Principal   *domain.Principal      `json:"-"`
+	Name        string                 `json:"name"`
+	Preferences CustomerPreferencesDTO `json:"preferences"`
+}
+
+type UpdateCustomerProfileUseCase struct {
+	repo     domain.CustomerRepository
+	accounts customerAccounts
+}
+
+func NewUpdateCustomerProfileUseCase(customerRepo domain.CustomerRepository) *UpdateCustomerProfileUseCase {
+	return &UpdateCustomerProfileUseCase{repo: customerRepo, accounts: customerAccounts{repo: customerRepo}}
+}
+
+func (uc *UpdateCustomerProfileUseCase) Execute(input UpdateCustomerProfileInputDTO) (*CustomerDTO, error) {
+
+	customer, err := uc.accounts.resolve(input.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := domain.CustomerPreferences{
+		TicketType:           domain.TicketType(input.Preferences.TicketType),
+		Zone:                 input.Preferences.Zone,
+		AcceptObstructedView: input.Preferences.AcceptObstructedView,
+		MarketingEmails:      input.Preferences.MarketingEmails,
+	}
+	if err := customer.UpdateProfile(input.Name, preferences); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateCustomer(customer); err != nil {
+		return nil, err
+	}
+
+	return newCustomerDTO(customer), nil
+}
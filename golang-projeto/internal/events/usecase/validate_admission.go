@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type ValidateAdmissionInputDTO struct {
+	OrganizationId string
+	EventId        string
+	Token          string
+	Principal      *domain.Principal
+}
+
+// ValidateAdmissionOutputDTO names the admission token used up by the
+// checkout, empty when the event had no active waiting room.
+type ValidateAdmissionOutputDTO struct {
+	ConsumedToken string
+}
+
+// ValidateAdmissionUseCase decides whether a checkout may go ahead: events
+// without an active waiting room are open to everyone, otherwise an
+// admitted, unexpired token is required. Sessions of a recurring event go
+// through the waiting room of the event. The token is bound to the
+// customer checking out and consumed, so it serves a single checkout;
+// RestoreAdmissionUseCase gives it back if that checkout fails.
+type ValidateAdmissionUseCase struct {
+	repo     domain.EventRepository
+	roomRepo domain.WaitingRoomRepository
+}
+
+func NewValidateAdmissionUseCase(repo domain.EventRepository, roomRepo domain.WaitingRoomRepository) *ValidateAdmissionUseCase {
+	return &ValidateAdmissionUseCase{repo: repo, roomRepo: roomRepo}
+}
+
+func (uc *ValidateAdmissionUseCase) Execute(input ValidateAdmissionInputDTO) (*ValidateAdmissionOutputDTO, error) {
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+	// A fila de um evento recorrente vale para todas as suas sessões.
+	roomEventId := event.Id
+	if event.IsSession() {
+		roomEventId = event.ParentId
+	}
+
+	room, err := uc.roomRepo.FindOrganizationWaitingRoom(input.OrganizationId, roomEventId)
+	if errors.Is(err, domain.ErrWaitingRoomNotFound) {
+		return &ValidateAdmissionOutputDTO{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !room.Active {
+		return &ValidateAdmissionOutputDTO{}, nil
+	}
+
+	if input.Token == "" {
+		return nil, domain.ErrAdmissionTokenRequired
+	}
+	entry, err := uc.roomRepo.FindWaitingRoomEntry(input.Token)
+	if errors.Is(err, domain.ErrWaitingRoomEntryNotFound) {
+		return nil, domain.ErrAdmissionTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := entry.ValidateAdmission(room.EventId, input.Principal, time.Now()); err != nil {
+		return nil, err
+	}
+
+	entry.Consume(input.Principal)
+	if err := uc.roomRepo.ConsumeWaitingRoomEntry(entry); err != nil {
+		return nil, err
+	}
+	return &ValidateAdmissionOutputDTO{ConsumedToken: entry.Token}, nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+// SpotLayoutDTO describes a seating plan, see domain.SpotLayout.
+// Attributes are keyed by spot name.
+type SpotLayoutDTO struct {
+	Rows        int                 `json:"rows"`
+	SeatsPerRow int                 `json:"seats_per_row"`
+	SkipRows    []string            `json:"skip_rows"`
+	SkipSeats   []int               `json:"skip_seats"`
+	Aisles      []int               `json:"aisles"`
+	Zones       []LayoutZoneDTO     `json:"zones"`
+	Attributes  map[string][]string `json:"attributes"`
+}
+
+type LayoutZoneDTO struct {
+	Name     string `json:"name"`
+	FirstRow string `json:"first_row"`
+	LastRow  string `json:"last_row"`
+}
+
+type AddressDTO struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+type VenueLayoutDTO struct {
+	Name  string `json:"name"`
+	Spots int    `json:"spots"`
+}
+
+type VenueDTO struct {
+	Id        string           `json:"id"`
+	Name      string           `json:"name"`
+	Address   AddressDTO       `json:"address"`
+	Latitude  float64          `json:"latitude"`
+	Longitude float64          `json:"longitude"`
+	Timezone  string           `json:"timezone"`
+	Capacity  int              `json:"capacity"`
+	Layouts   []VenueLayoutDTO `json:"layouts,omitempty"`
+	CreatedAt string           `json:"created_at"`
+}
+
+func (d SpotLayoutDTO) toDomain() domain.SpotLayout {
+	layout := domain.SpotLayout{
+		Rows:        d.Rows,
+		SeatsPerRow: d.SeatsPerRow,
+		SkipRows:    d.SkipRows,
+		SkipSeats:   d.SkipSeats,
+		Aisles:      d.Aisles,
+	}
+	if len(d.Attributes) > 0 {
+		layout.Attributes = make(map[string][]domain.SpotAttribute, len(d.Attributes))
+		for name, attributes := range d.Attributes {
+			for _, attribute := range attributes {
+				layout.Attributes[name] = append(layout.Attributes[name], domain.SpotAttribute(attribute))
+			}
+		}
+	}
+	for _, zone := range d.Zones {
+		layout.Zones = append(layout.Zones, domain.LayoutZone{
+			Name:     zone.Name,
+			FirstRow: zone.FirstRow,
+			LastRow:  zone.LastRow,
+		})
+	}
+	return layout
+}
+
+func (d AddressDTO) toDomain() domain.Address {
+	return domain.Address{
+		Street:     d.Street,
+		City:       d.City,
+		State:      d.State,
+		PostalCode: d.PostalCode,
+		Country:    d.Country,
+	}
+}
+
+func newVenueLayoutDTO(layout *domain.VenueLayout) VenueLayoutDTO {
+	// Layouts guardados já foram validados.
+	names, _ := layout.Layout.SpotNames()
+	return VenueLayoutDTO{Name: layout.Name, Spots: len(names)}
+}
+
+func newVenueDTO(venue *domain.Venue) VenueDTO {
+	venueDTO := VenueDTO{
+		Id:   venue.Id,
+		Name: venue.Name,
+		Address: AddressDTO{
+			Street:     venue.Address.Street,
+			City:       venue.Address.City,
+			State:      venue.Address.State,
+			PostalCode: venue.Address.PostalCode,
+			Country:    venue.Address.Country,
+		},
+		Latitude:  venue.Latitude,
+		Longitude: venue.Longitude,
+		Timezone:  venue.Timezone,
+		Capacity:  venue.Capacity,
+		CreatedAt: venue.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	for i := range venue.Layouts {
+		venueDTO.Layouts = append(venueDTO.Layouts, newVenueLayoutDTO(&venue.Layouts[i]))
+	}
+	return venueDTO
+}
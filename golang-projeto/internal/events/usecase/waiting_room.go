@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type WaitingRoomDTO struct {
+	EventId             string `json:"event_id"`
+	Active              bool   `json:"active"`
+	AdmissionRate       int    `json:"admission_rate"`
+	AdmissionTTLSeconds int    `json:"admission_ttl_seconds"`
+	Waiting             int    `json:"waiting"`
+}
+
+// WaitingRoomEntryDTO is what a queued customer polls. Position and
+// EstimatedWaitSeconds are only set while waiting, ExpiresAt once
+// admitted.
+type WaitingRoomEntryDTO struct {
+	Token                string `json:"token"`
+	EventId              string `json:"event_id"`
+	Status               string `json:"status"`
+	Position             int    `json:"position,omitempty"`
+	EstimatedWaitSeconds int    `json:"estimated_wait_seconds,omitempty"`
+	ExpiresAt            string `json:"expires_at,omitempty"`
+}
+
+func newWaitingRoomDTO(room *domain.WaitingRoom, waiting int) *WaitingRoomDTO {
+	return &WaitingRoomDTO{
+		EventId:             room.EventId,
+		Active:              room.Active,
+		AdmissionRate:       room.AdmissionRate,
+		AdmissionTTLSeconds: int(room.AdmissionTTL / time.Second),
+		Waiting:             waiting,
+	}
+}
+
+// waitingRoomEntryDTO reports the position of a waiting entry in the queue
+// of its room.
+func waitingRoomEntryDTO(roomRepo domain.WaitingRoomRepository, room *domain.WaitingRoom, entry *domain.WaitingRoomEntry) (*WaitingRoomEntryDTO, error) {
+	dto := &WaitingRoomEntryDTO{
+		Token:   entry.Token,
+		EventId: entry.EventId,
+		Status:  string(entry.Status),
+	}
+	if entry.Status == domain.WaitingRoomEntryAdmitted {
+		dto.ExpiresAt = entry.ExpiresAt.UTC().Format(time.RFC3339)
+		return dto, nil
+	}
+	if entry.Status == domain.WaitingRoomEntryConsumed {
+		return dto, nil
+	}
+
+	ahead, err := roomRepo.CountWaitingAhead(entry.EventId, entry.Seq)
+	if err != nil {
+		return nil, err
+	}
+	dto.Position = ahead + 1
+	dto.EstimatedWaitSeconds = int(room.EstimatedWait(dto.Position) / time.Second)
+	return dto, nil
+}
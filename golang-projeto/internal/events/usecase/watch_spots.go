@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type WatchSpotsInputDTO struct {
+	OrganizationId string
+	EventId        string
+	LastEventId    string
+}
+
+type SpotChangeDTO struct {
+	Seq           uint64 `json:"-"`
+	SpotId        string `json:"spot_id"`
+	Spot          string `json:"spot"`
+	Zone          string `json:"zone,omitempty"`
+	Change        string `json:"change"`
+	Status        string `json:"status"`
+	HoldExpiresAt string `json:"hold_expires_at,omitempty"`
+	At            string `json:"at"`
+}
+
+// WatchSpotsOutputDTO is a live stream of spot changes. Missed holds the
+// changes since LastEventId; when Reset is set they were lost and the
+// client must reload the spots. Close must be called when done.
+type WatchSpotsOutputDTO struct {
+	Reset   bool
+	Missed  []SpotChangeDTO
+	Changes <-chan domain.SpotChange
+	Close   func()
+}
+
+type WatchSpotsUseCase struct {
+	repo domain.EventRepository
+	hub  domain.SpotAvailabilityHub
+}
+
+func NewWatchSpotsUseCase(repo domain.EventRepository, hub domain.SpotAvailabilityHub) *WatchSpotsUseCase {
+	return &WatchSpotsUseCase{repo: repo, hub: hub}
+}
+
+func (uc *WatchSpotsUseCase) Execute(input WatchSpotsInputDTO) (*WatchSpotsOutputDTO, error) {
+
+	// Buscando dados em db.
+	event, err := uc.repo.FindOrganizationEvent(input.OrganizationId, input.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Um Last-Event-ID inválido é tratado como perda de mudanças.
+	var lastSeq uint64
+	reset := false
+	if input.LastEventId != "" {
+		if lastSeq, err = strconv.ParseUint(input.LastEventId, 10, 64); err != nil {
+			reset = true
+		}
+	}
+
+	subscription := uc.hub.Subscribe(event.Id, lastSeq)
+	missed := make([]SpotChangeDTO, len(subscription.Missed))
+	for i, change := range subscription.Missed {
+		missed[i] = NewSpotChangeDTO(change)
+	}
+
+	return &WatchSpotsOutputDTO{
+		Reset:   reset || subscription.Reset,
+		Missed:  missed,
+		Changes: subscription.Changes,
+		Close:   subscription.Close,
+	}, nil
+}
+
+func NewSpotChangeDTO(change domain.SpotChange) SpotChangeDTO {
+	status := domain.SpotStatusAvailable
+	switch change.Kind {
+	case domain.SpotChangeSold:
+		status = domain.SpotStatusSold
+	case domain.SpotChangeHeld:
+		status = domain.SpotStatusHeld
+	}
+
+	dto := SpotChangeDTO{
+		Seq:    change.Seq,
+		SpotId: change.SpotId,
+		Spot:   change.SpotName,
+		Zone:   change.Zone,
+		Change: string(change.Kind),
+		Status: string(status),
+		At:     change.At.UTC().Format(time.RFC3339),
+	}
+	if !change.HoldExpiresAt.IsZero() {
+		dto.HoldExpiresAt = change.HoldExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/daffc/imersao18/golang/internal/events/domain"
+)
+
+type WebhookSubscriptionDTO struct {
+	Id         string   `json:"id"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type WebhookDeliveryDTO struct {
+	Id             string `json:"id"`
+	WebhookId      string `json:"webhook_id"`
+	DomainEventId  string `json:"domain_event_id"`
+	EventName      string `json:"event_name"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+}
+
+func newWebhookDeliveryDTO(delivery *domain.WebhookDelivery) WebhookDeliveryDTO {
+	dto := WebhookDeliveryDTO{
+		Id:             delivery.Id,
+		WebhookId:      delivery.SubscriptionId,
+		DomainEventId:  delivery.DomainEventId,
+		EventName:      delivery.EventName,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		dto.NextAttemptAt = delivery.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		dto.DeliveredAt = delivery.DeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
@@ -0,0 +1,13 @@
+ALTER TABLE spots
+    ADD COLUMN zone VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN hold_id VARCHAR(36) NULL,
+    ADD COLUMN hold_expires_at DATETIME NULL,
+    ADD INDEX idx_spots_hold_id (hold_id);
+
+CREATE TABLE holds (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    ticket_type VARCHAR(10) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    INDEX idx_holds_event_id (event_id)
+);
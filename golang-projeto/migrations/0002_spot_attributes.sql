@@ -0,0 +1,2 @@
+ALTER TABLE spots
+    ADD COLUMN attributes VARCHAR(100) NOT NULL DEFAULT '';
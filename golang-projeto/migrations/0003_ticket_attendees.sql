@@ -0,0 +1,3 @@
+ALTER TABLE tickets
+    ADD COLUMN attendee_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN attendee_birth_date DATE NULL;
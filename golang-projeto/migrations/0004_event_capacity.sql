@@ -0,0 +1,9 @@
+ALTER TABLE events
+    ADD COLUMN seating_mode VARCHAR(20) NOT NULL DEFAULT 'seated',
+    ADD COLUMN sold_tickets INT NOT NULL DEFAULT 0;
+
+UPDATE events e
+SET e.sold_tickets = (SELECT COUNT(*) FROM tickets t WHERE t.event_id = e.id);
+
+ALTER TABLE tickets
+    MODIFY COLUMN spot_id VARCHAR(36) NULL;
@@ -0,0 +1,23 @@
+CREATE TABLE orders (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    card_hash VARCHAR(255) NOT NULL,
+    total DECIMAL(10, 2) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_orders_event_email (event_id, email, created_at),
+    INDEX idx_orders_event_card (event_id, card_hash)
+);
+
+ALTER TABLE tickets
+    ADD COLUMN order_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_tickets_order_id (order_id);
+
+CREATE TABLE event_purchase_limits (
+    event_id VARCHAR(36) NOT NULL PRIMARY KEY,
+    max_per_order INT NOT NULL DEFAULT 0,
+    max_per_email INT NOT NULL DEFAULT 0,
+    max_per_card INT NOT NULL DEFAULT 0,
+    max_per_window INT NOT NULL DEFAULT 0,
+    window_seconds BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,15 @@
+ALTER TABLE tickets
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE TABLE waitlist_entries (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    hold_id VARCHAR(36) NOT NULL DEFAULT '',
+    offer_expires_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_waitlist_event_status (event_id, status, created_at),
+    INDEX idx_waitlist_offer_expires_at (status, offer_expires_at)
+);
@@ -0,0 +1,29 @@
+ALTER TABLE tickets
+    ADD COLUMN holder_email VARCHAR(255) NOT NULL DEFAULT '';
+
+UPDATE tickets t
+JOIN orders o ON o.id = t.order_id
+SET t.holder_email = o.email;
+
+CREATE TABLE ticket_transfers (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    ticket_id VARCHAR(36) NOT NULL,
+    from_email VARCHAR(255) NOT NULL,
+    to_email VARCHAR(255) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    accepted_at DATETIME NULL,
+    INDEX idx_ticket_transfers_ticket (ticket_id, status),
+    INDEX idx_ticket_transfers_expires (status, expires_at)
+);
+
+CREATE TABLE ticket_holders (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    ticket_id VARCHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    transfer_id VARCHAR(36) NOT NULL DEFAULT '',
+    since DATETIME NOT NULL,
+    INDEX idx_ticket_holders_ticket (ticket_id, since)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE tickets
+    ADD COLUMN used_at DATETIME NULL,
+    ADD COLUMN gate_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD INDEX idx_tickets_event_status (event_id, status);
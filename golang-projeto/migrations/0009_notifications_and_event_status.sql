@@ -0,0 +1,20 @@
+ALTER TABLE events
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    ADD COLUMN reminded_at DATETIME NULL,
+    ADD INDEX idx_events_status_date (status, date);
+
+CREATE TABLE notifications (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    kind VARCHAR(40) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    dedup_key VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    next_attempt_at DATETIME NOT NULL,
+    sent_at DATETIME NULL,
+    INDEX idx_notifications_due (status, next_attempt_at)
+);
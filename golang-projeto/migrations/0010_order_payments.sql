@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN payment_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN payment_status VARCHAR(20) NOT NULL DEFAULT '';
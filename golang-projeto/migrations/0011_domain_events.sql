@@ -0,0 +1,11 @@
+CREATE TABLE domain_events (
+    seq BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    id VARCHAR(36) NOT NULL UNIQUE,
+    name VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    payload JSON NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    published_at DATETIME NULL,
+    INDEX idx_domain_events_unpublished (published_at, seq)
+);
@@ -0,0 +1,27 @@
+CREATE TABLE webhook_subscriptions (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types JSON NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL,
+    domain_event_id VARCHAR(36) NOT NULL,
+    event_name VARCHAR(64) NOT NULL,
+    body JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    next_attempt_at DATETIME NOT NULL,
+    delivered_at DATETIME NULL,
+    UNIQUE KEY uq_webhook_deliveries_event (subscription_id, domain_event_id),
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_log (subscription_id, status, created_at),
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE waiting_rooms (
+    event_id VARCHAR(36) NOT NULL PRIMARY KEY,
+    active BOOLEAN NOT NULL,
+    admission_rate INT NOT NULL,
+    admission_ttl_seconds INT NOT NULL,
+    last_admission_at DATETIME NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE TABLE waiting_room_entries (
+    seq BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    token VARCHAR(36) NOT NULL UNIQUE,
+    event_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    joined_at DATETIME NOT NULL,
+    admitted_at DATETIME NULL,
+    expires_at DATETIME NULL,
+    INDEX idx_waiting_room_entries_queue (event_id, status, seq)
+);
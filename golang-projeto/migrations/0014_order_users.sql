@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN user_id VARCHAR(255) NOT NULL DEFAULT '',
+    ADD INDEX idx_orders_user (user_id);
@@ -0,0 +1,21 @@
+CREATE TABLE customers (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(120) NOT NULL DEFAULT '',
+    emails JSON NOT NULL,
+    preferred_ticket_type VARCHAR(20) NOT NULL DEFAULT '',
+    preferred_zone VARCHAR(255) NOT NULL DEFAULT '',
+    accept_obstructed_view BOOLEAN NOT NULL DEFAULT FALSE,
+    marketing_emails BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+ALTER TABLE orders
+    ADD COLUMN customer_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_orders_customer (customer_id);
+
+ALTER TABLE tickets
+    ADD COLUMN customer_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_tickets_customer (customer_id),
+    ADD INDEX idx_tickets_holder_email (holder_email);
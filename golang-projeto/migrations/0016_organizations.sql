@@ -0,0 +1,45 @@
+CREATE TABLE organizations (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    support_email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE organization_hosts (
+    host VARCHAR(255) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+
+CREATE TABLE organization_partners (
+    organization_id VARCHAR(36) NOT NULL,
+    partner_id INT NOT NULL,
+    PRIMARY KEY (organization_id, partner_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+
+CREATE TABLE organization_admins (
+    organization_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (organization_id, user_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+
+INSERT INTO organizations (id, slug, name, created_at)
+SELECT UUID(), LOWER(REPLACE(TRIM(organization), ' ', '-')), organization, UTC_TIMESTAMP()
+FROM (SELECT DISTINCT organization FROM events) names;
+
+ALTER TABLE events
+    ADD COLUMN organization_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_events_organization (organization_id);
+
+UPDATE events e
+JOIN organizations o ON o.name = e.organization
+SET e.organization_id = o.id;
+
+INSERT INTO organization_partners (organization_id, partner_id)
+SELECT DISTINCT organization_id, partner_id
+FROM events;
@@ -0,0 +1,29 @@
+CREATE TABLE venues (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    street VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    state VARCHAR(255) NOT NULL DEFAULT '',
+    postal_code VARCHAR(32) NOT NULL DEFAULT '',
+    country VARCHAR(64) NOT NULL DEFAULT '',
+    latitude DECIMAL(9, 6) NOT NULL,
+    longitude DECIMAL(9, 6) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    capacity INT NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_venues_organization (organization_id, name),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+
+CREATE TABLE venue_layouts (
+    venue_id VARCHAR(36) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    layout JSON NOT NULL,
+    PRIMARY KEY (venue_id, name),
+    FOREIGN KEY (venue_id) REFERENCES venues(id)
+);
+
+ALTER TABLE events
+    ADD COLUMN venue_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_events_venue_date (venue_id, date);
@@ -0,0 +1,6 @@
+ALTER TABLE events
+    ADD COLUMN parent_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD COLUMN sales_start_at DATETIME NULL,
+    ADD COLUMN sales_end_at DATETIME NULL,
+    ADD COLUMN recurrence JSON NULL,
+    ADD INDEX idx_events_parent_date (parent_id, date);
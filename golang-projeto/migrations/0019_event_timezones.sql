@@ -0,0 +1,6 @@
+ALTER TABLE events
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE events e
+JOIN venues v ON v.id = e.venue_id
+SET e.timezone = v.timezone;
@@ -0,0 +1,15 @@
+CREATE TABLE event_sales_phases (
+    event_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    quota INT NOT NULL DEFAULT 0,
+    sold_tickets INT NOT NULL DEFAULT 0,
+    access_code_hash VARCHAR(64) NOT NULL DEFAULT '',
+    PRIMARY KEY (event_id, kind),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+ALTER TABLE tickets
+    ADD COLUMN sales_phase VARCHAR(16) NOT NULL DEFAULT '';
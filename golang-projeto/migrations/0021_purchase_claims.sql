@@ -0,0 +1,9 @@
+CREATE TABLE purchase_claims (
+    order_id VARCHAR(36) NOT NULL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    card_hash VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_purchase_claims_event (event_id, created_at)
+);
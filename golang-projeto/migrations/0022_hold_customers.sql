@@ -0,0 +1,6 @@
+ALTER TABLE holds
+    ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN quantity INT NOT NULL DEFAULT 0;
+ALTER TABLE orders
+    ADD COLUMN hold_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_orders_hold (hold_id);
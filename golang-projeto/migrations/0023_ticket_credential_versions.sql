@@ -0,0 +1,2 @@
+ALTER TABLE tickets
+    ADD COLUMN credential_version INT NOT NULL DEFAULT 0;
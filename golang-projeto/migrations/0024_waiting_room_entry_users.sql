@@ -0,0 +1,2 @@
+ALTER TABLE waiting_room_entries
+    ADD COLUMN user_id VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN organization_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_webhook_subscriptions_organization (organization_id);
@@ -0,0 +1,2 @@
+ALTER TABLE waitlist_entries
+    ADD COLUMN ticket_type VARCHAR(10) NOT NULL DEFAULT 'full';
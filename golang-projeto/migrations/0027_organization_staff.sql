@@ -0,0 +1,6 @@
+CREATE TABLE organization_staff (
+    organization_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (organization_id, user_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);